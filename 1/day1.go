@@ -0,0 +1,94 @@
+// https://adventofcode.com/2024/day/1
+// aoc2024 run 1 --input 1/1.txt
+
+package day1
+
+import (
+	"context"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func count(x int64, s []int64) int64 {
+	// could be more efficient since it is sorted but meh
+	var count int64 = 0
+	for _, v := range s {
+		if v == x {
+			count++
+		}
+	}
+	return count
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 1, New: func() aoc.Solver { return &solver{} }, Generate: generate})
+}
+
+// parseLists reads the left and right location ID lists, one pair per line
+func parseLists(input string) ([]int64, []int64, error) {
+	var l, r []int64
+	for i, line := range parse.Lines(input) {
+		strs := strings.Fields(line)
+		if len(strs) != 2 {
+			return nil, nil, parse.Errorf(i+1, "expected '<left> <right>', got %q", line)
+		}
+		lv, err := strconv.ParseInt(strs[0], 10, 64)
+		if err != nil {
+			return nil, nil, parse.Errorf(i+1, "bad left location ID %q", strs[0])
+		}
+		rv, err := strconv.ParseInt(strs[1], 10, 64)
+		if err != nil {
+			return nil, nil, parse.Errorf(i+1, "bad right location ID %q", strs[1])
+		}
+		l, r = append(l, lv), append(r, rv)
+	}
+	return l, r, nil
+}
+
+type solver struct {
+	l, r []int64
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.l, s.r, err = parseLists(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	l, r := slices.Clone(s.l), slices.Clone(s.r)
+	slices.Sort(l)
+	slices.Sort(r)
+
+	var totalDist int64 = 0
+	for i := 0; i < len(l); i++ {
+		totalDist += abs(r[i] - l[i])
+	}
+	return totalDist, nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	var similarityScore int64 = 0
+	for _, v := range s.l {
+		c := count(v, s.r)
+		similarityScore += v * c
+	}
+	return similarityScore, nil
+}
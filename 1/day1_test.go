@@ -0,0 +1,36 @@
+package day1
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 1) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 1, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 1, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 1, 2) }
+
+func TestParseLists(t *testing.T) {
+	l, r, err := parseLists("3   4\n4   3\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 2 || l[0] != 3 || l[1] != 4 || r[0] != 4 || r[1] != 3 {
+		t.Errorf("got %v %v", l, r)
+	}
+	if _, _, err := parseLists("3   4\n4\n"); err == nil {
+		t.Error("expected error for missing right ID")
+	}
+}
+
+func FuzzParseLists(f *testing.F) {
+	aoctest.Fuzz(f, 1, func(t *testing.T, part int, answer aoc.Answer) {
+		if part == 1 && answer.(int64) < 0 {
+			t.Errorf("total distance %v is negative", answer)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package day1
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate makes size pairs of location IDs; some right IDs repeat left ones,
+// so the similarity score is non-zero
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	var sb strings.Builder
+	left := make([]int, size)
+	for i := range left {
+		left[i] = 10000 + rng.IntN(90000)
+	}
+	for i := range left {
+		right := 10000 + rng.IntN(90000)
+		if rng.IntN(3) == 0 {
+			right = left[rng.IntN(size)]
+		}
+		fmt.Fprintf(&sb, "%d   %d\n", left[i], right)
+	}
+	return sb.String()
+}
@@ -0,0 +1,109 @@
+// https://adventofcode.com/2024/day/10
+// aoc2024 run 10 --input 10/10.txt
+
+package day10
+
+import (
+	"context"
+	"io"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/graph"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/memo"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Island struct {
+	puzzle  string
+	topoMap *grid.Grid[byte] // height ('0'- '9')
+}
+
+// NewIsland parses the topographic map; '.' is impassable
+func NewIsland(puzzle string) (*Island, error) {
+	topoMap, err := grid.ParseOnly(puzzle, "0123456789.")
+	if err != nil {
+		return nil, err
+	}
+	return &Island{puzzle: puzzle, topoMap: topoMap}, nil
+}
+
+func (isld *Island) IsInBounds(p grid.Point) bool {
+	return isld.topoMap.InBounds(p)
+}
+
+func (isld *Island) GetCellVal(pt grid.Point) byte {
+	return isld.topoMap.At(pt)
+}
+
+// Trails lists the steps a hike can take from a point: up exactly one
+// height, so never onto or off impassable '.'
+func (isld *Island) Trails() graph.Neighbors[grid.Point] {
+	return graph.Grid4(isld.topoMap, func(from, to grid.Point) bool {
+		return isld.topoMap.At(to) == isld.topoMap.At(from)+1
+	})
+}
+
+func (isld *Island) isPeak(pt grid.Point) bool {
+	return isld.topoMap.At(pt) == '9'
+}
+
+// SumAllTrailheadScores returns the total over trailheads of their scores,
+// the peaks they reach, and their ratings, the distinct trails to them.
+// ratings memoises the trails from each point, and may be shared by calls.
+func (isld *Island) SumAllTrailheadScores(ratings *memo.Cache[grid.Point, int]) (int, int) {
+	trails := isld.Trails()
+	totalScore, totalRating := 0, 0
+	for pt, cell := range isld.topoMap.All() {
+		if cell != '0' {
+			continue // only handle trailheads
+		}
+		for reached := range graph.Reachable(trails, pt) {
+			if isld.isPeak(reached) {
+				totalScore++
+			}
+		}
+		totalRating += graph.CountPathsWith(trails, ratings, pt, isld.isPeak)
+	}
+	return totalScore, totalRating
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (isld *Island) TopoMapView() string {
+	return grid.Text(isld.topoMap)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 10, New: func() aoc.Solver { return &solver{} }})
+}
+
+type solver struct {
+	isld *Island
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.isld, err = NewIsland(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	aoc.Logger(ctx).Debug("island", "topo", aoc.Lazy(s.isld.TopoMapView))
+	score, _ := s.isld.SumAllTrailheadScores(memo.New[grid.Point, int](0))
+	return score, nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	ratings := memo.New[grid.Point, int](0)
+	_, rating := s.isld.SumAllTrailheadScores(ratings)
+	aoc.Logger(ctx).Info("memo", "ratings", ratings.Stats())
+	return rating, nil
+}
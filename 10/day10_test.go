@@ -0,0 +1,43 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/memo"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 10) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 10, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 10, 2) }
+
+func TestSumAllTrailheadScores(t *testing.T) {
+	isld, err := NewIsland("0123\n1234\n8765\n9876")
+	if err != nil {
+		t.Fatal(err)
+	}
+	score, rating := isld.SumAllTrailheadScores(memo.New[grid.Point, int](0))
+	if score != 1 || rating != 16 {
+		t.Errorf("got score %d rating %d, want 1 16", score, rating)
+	}
+}
+
+func FuzzNewIsland(f *testing.F) {
+	aoctest.Fuzz(f, 10, nil)
+}
+
+// every trailhead that reaches a peak has at least one trail there
+func FuzzTrailheads(f *testing.F) {
+	aoctest.Seed(f, 10)
+	f.Fuzz(func(t *testing.T, input string) {
+		isld, err := NewIsland(input)
+		if err != nil {
+			return
+		}
+		if score, rating := isld.SumAllTrailheadScores(memo.New[grid.Point, int](0)); score < 0 || rating < score {
+			t.Errorf("score %d, rating %d", score, rating)
+		}
+	})
+}
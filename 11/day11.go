@@ -0,0 +1,320 @@
+// https://adventofcode.com/2024/day/11
+// aoc2024 run 11 --input 11/11.txt
+
+package day11
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/checked"
+	"github.com/neomantra/aoc2024/memo"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+func tenToPower(n int) int {
+	switch n {
+	case 0:
+		return 1
+	case 1:
+		return 10
+	case 2:
+		return 100
+	case 3:
+		return 1000
+	case 4:
+		return 10000
+	case 5:
+		return 100000
+	case 6:
+		return 1000000
+	}
+	result := 1
+	for i := 0; i < n; i++ {
+		result *= 10
+	}
+	return result
+}
+
+func countDigits(num int) int {
+	numDigits := 0
+	for num > 0 {
+		num /= 10
+		numDigits++
+	}
+	return numDigits
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type StoneRow struct {
+	puzzle string
+	stones []int
+
+	// how many stones a stone becomes after some blinks, kept across
+	// counts, which may run concurrently
+	counts    *memo.Sync[Pair, int]
+	bigCounts *memo.Sync[Pair, *big.Int]
+}
+
+// NewStoneRow parses a line of non-negative stone numbers
+func NewStoneRow(puzzle string) (*StoneRow, error) {
+	stoneRow := StoneRow{
+		puzzle:    puzzle,
+		counts:    memo.NewSync[Pair, int](0),
+		bigCounts: memo.NewSync[Pair, *big.Int](0),
+	}
+
+	lines := parse.Lines(puzzle)
+	if len(lines) != 1 {
+		return nil, parse.Errorf(len(lines), "expected one line of stones, got %d lines", len(lines))
+	}
+	for _, field := range strings.Fields(lines[0]) {
+		num, err := parse.Int(1, field)
+		if err != nil {
+			return nil, err
+		}
+		if num < 0 {
+			return nil, parse.Errorf(1, "negative stone %d", num)
+		}
+		stoneRow.stones = append(stoneRow.stones, num)
+	}
+	if len(stoneRow.stones) == 0 {
+		return nil, parse.Errorf(1, "no stones")
+	}
+	return &stoneRow, nil
+}
+
+// errStoneOverflow is a stone whose engraving outgrew an int
+func errStoneOverflow(stone int) error {
+	return fmt.Errorf("%w: stone %d times 2024", aoc.ErrOverflow, stone)
+}
+
+func (sr *StoneRow) Blink() error {
+	newStones := make([]int, 0, len(sr.stones)*2)
+	for _, stone := range sr.stones {
+		a, b, split, err := nextStones(stone)
+		if err != nil {
+			return err
+		}
+		newStones = append(newStones, a)
+		if split {
+			newStones = append(newStones, b)
+		}
+	}
+	sr.stones = newStones
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Pair struct {
+	Stone     int
+	NumBlinks int
+}
+
+func NewPair(stone, numBlinks int) Pair { return Pair{Stone: stone, NumBlinks: numBlinks} }
+
+// nextStones returns the one or two stones a stone becomes after a blink
+func nextStones(stone int) (a, b int, split bool, err error) {
+	if stone == 0 {
+		return 1, 0, false, nil
+	}
+	numDigits := countDigits(stone)
+	if (numDigits & 1) != 0 {
+		next, ok := checked.Mul(stone, 2024)
+		if !ok {
+			return 0, 0, false, errStoneOverflow(stone)
+		}
+		return next, 0, false, nil
+	}
+	// split odd stone in half
+	factor := tenToPower(numDigits / 2)
+	a = stone / factor
+	b = stone - (a * factor)
+	return a, b, true, nil
+}
+
+// breakTimes counts the stones stone becomes after numBlinks
+func (sr *StoneRow) breakTimes(stone int, numBlinks int) (int, error) {
+	if numBlinks <= 0 {
+		return 1, nil
+	}
+	cachedCount, ok := sr.counts.Get(NewPair(stone, numBlinks))
+	if ok {
+		return cachedCount, nil
+	}
+
+	a, b, split, err := nextStones(stone)
+	if err != nil {
+		return 0, err
+	}
+	count, err := sr.breakTimes(a, numBlinks-1)
+	if err != nil {
+		return 0, err
+	}
+	if split {
+		countB, err := sr.breakTimes(b, numBlinks-1)
+		if err != nil {
+			return 0, err
+		}
+		if count, ok = checked.Add(count, countB); !ok {
+			return 0, fmt.Errorf("%w: counting stones", aoc.ErrOverflow)
+		}
+	}
+	sr.counts.Put(NewPair(stone, numBlinks), count)
+	return count, nil
+}
+
+// breakTimesBig is breakTimes counting with math/big; its results are
+// shared through the memo, so must not be modified
+func (sr *StoneRow) breakTimesBig(stone int, numBlinks int) (*big.Int, error) {
+	if numBlinks <= 0 {
+		return big.NewInt(1), nil
+	}
+	if cachedCount, ok := sr.bigCounts.Get(NewPair(stone, numBlinks)); ok {
+		return cachedCount, nil
+	}
+
+	a, b, split, err := nextStones(stone)
+	if err != nil {
+		return nil, err
+	}
+	count, err := sr.breakTimesBig(a, numBlinks-1)
+	if err != nil {
+		return nil, err
+	}
+	if split {
+		countB, err := sr.breakTimesBig(b, numBlinks-1)
+		if err != nil {
+			return nil, err
+		}
+		count = new(big.Int).Add(count, countB)
+	}
+	sr.bigCounts.Put(NewPair(stone, numBlinks), count)
+	return count, nil
+}
+
+func (sr *StoneRow) CountAfterBlinking(numBlinks int) (int, error) {
+	// go over each stone, processing it numBlinks times
+	numStones := 0
+	for i := 0; i < len(sr.stones); i++ {
+		count, err := sr.breakTimes(sr.stones[i], numBlinks)
+		if err != nil {
+			return 0, err
+		}
+		var ok bool
+		if numStones, ok = checked.Add(numStones, count); !ok {
+			return 0, fmt.Errorf("%w: counting stones", aoc.ErrOverflow)
+		}
+	}
+	return numStones, nil
+}
+
+// CountAfterBlinkingBig is CountAfterBlinking counting with math/big
+func (sr *StoneRow) CountAfterBlinkingBig(numBlinks int) (*big.Int, error) {
+	numStones := new(big.Int)
+	for i := 0; i < len(sr.stones); i++ {
+		count, err := sr.breakTimesBig(sr.stones[i], numBlinks)
+		if err != nil {
+			return nil, err
+		}
+		numStones.Add(numStones, count)
+	}
+	return numStones, nil
+}
+
+// MemoStats returns the stats of the memo of counts, or with math/big
+func (sr *StoneRow) MemoStats(big bool) memo.Stats {
+	if big {
+		return sr.bigCounts.Stats()
+	}
+	return sr.counts.Stats()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (sr *StoneRow) View() string {
+	var sb strings.Builder
+	for _, stone := range sr.stones {
+		sb.Write([]byte(strconv.Itoa(stone)))
+		sb.WriteByte(' ')
+	}
+	return sb.String()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 11, New: func() aoc.Solver { return &solver{blinks1: 25, blinks2: 75} }, Generate: generate, Big: true})
+}
+
+type solver struct {
+	stoneRow         *StoneRow
+	blinks1, blinks2 int // blinks for each part
+}
+
+func (s *solver) DefineParams(fs *flag.FlagSet) {
+	fs.IntVar(&s.blinks1, "blinks1", s.blinks1, "blinks for part 1")
+	fs.IntVar(&s.blinks2, "blinks2", s.blinks2, "blinks for part 2")
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.stoneRow, err = NewStoneRow(input)
+	return err
+}
+
+// count counts the stones after numBlinks, with math/big if ctx asks
+func (s *solver) count(ctx context.Context, numBlinks int) (aoc.Answer, error) {
+	var count aoc.Answer
+	var err error
+	if aoc.Big(ctx) {
+		count, err = s.stoneRow.CountAfterBlinkingBig(numBlinks)
+	} else {
+		count, err = s.stoneRow.CountAfterBlinking(numBlinks)
+	}
+	aoc.Logger(ctx).Info("memo", "blinks", numBlinks, "counts", s.stoneRow.MemoStats(aoc.Big(ctx)))
+	return count, err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	aoc.Logger(ctx).Debug("stones", "row", aoc.Lazy(s.stoneRow.View))
+	return s.count(ctx, s.blinks1)
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	return s.count(ctx, s.blinks2)
+}
+
+// Verify checks the memoised count against blinking every stone, for as
+// many blinks as part 1
+func (s *solver) Verify(ctx context.Context) error {
+	naive := &StoneRow{stones: slices.Clone(s.stoneRow.stones)}
+	for numBlinks := 1; numBlinks <= s.blinks1; numBlinks++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := naive.Blink(); err != nil {
+			return err
+		}
+		fast, err := s.stoneRow.CountAfterBlinking(numBlinks)
+		if err != nil {
+			return err
+		}
+		if fast != len(naive.stones) {
+			return &aoc.Mismatch{What: fmt.Sprintf("stones after %d blinks", numBlinks), Fast: fast, Naive: len(naive.stones)}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package day11
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+	"github.com/neomantra/aoc2024/memo"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 11) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 11, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 11, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 11, 2) }
+
+func TestBlink(t *testing.T) {
+	sr, err := NewStoneRow("0 1 10 99 999")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sr.Blink(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sr.View(), "1 2024 1 0 9 9 2021976 "; got != want {
+		t.Errorf("Blink = %q, want %q", got, want)
+	}
+}
+
+func TestCountAfterBlinking(t *testing.T) {
+	sr, err := NewStoneRow("125 17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := sr.CountAfterBlinking(6); got != 22 || err != nil {
+		t.Errorf("CountAfterBlinking(6) = %d, %v, want 22", got, err)
+	}
+	if got, err := sr.CountAfterBlinkingBig(6); got.Int64() != 22 || err != nil {
+		t.Errorf("CountAfterBlinkingBig(6) = %v, %v, want 22", got, err)
+	}
+}
+
+func TestMemo(t *testing.T) {
+	// each row counts with its own memo, which a second count reuses
+	a, _ := NewStoneRow("125 17")
+	b, _ := NewStoneRow("125 17")
+	a.CountAfterBlinking(25)
+	first := a.MemoStats(false)
+	a.CountAfterBlinking(25)
+	if s := a.MemoStats(false); s.Misses != first.Misses || s.Size != first.Size || s.Hits <= first.Hits {
+		t.Errorf("counting again: stats %v after %v, want only hits", s, first)
+	}
+	if s := b.MemoStats(false); s != (memo.Stats{}) {
+		t.Errorf("another row's stats %v, want none", s)
+	}
+}
+
+func TestCountAfterBlinkingOverflow(t *testing.T) {
+	sr, err := NewStoneRow("125 17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the count passes 2^63 somewhere before 300 blinks
+	if _, err := sr.CountAfterBlinking(300); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("CountAfterBlinking(300): got %v, want ErrOverflow", err)
+	}
+	got, err := sr.CountAfterBlinkingBig(300)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.IsInt64() {
+		t.Errorf("CountAfterBlinkingBig(300) = %v, expected past an int64", got)
+	}
+
+	huge, err := NewStoneRow("1000000000000000000") // 19 digits, times 2024
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := huge.Blink(); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("Blink: got %v, want ErrOverflow", err)
+	}
+	if _, err := huge.CountAfterBlinkingBig(1); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("CountAfterBlinkingBig: got %v, want ErrOverflow for the stone itself", err)
+	}
+}
+
+func FuzzNewStoneRow(f *testing.F) {
+	aoctest.Fuzz(f, 11, nil)
+}
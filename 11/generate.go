@@ -0,0 +1,22 @@
+package day11
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// generate makes a row of size stones of one to seven digits, with the
+// odd zero
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	stones := make([]string, size)
+	for i := range stones {
+		stone := 0
+		if rng.IntN(8) != 0 {
+			stone = rng.IntN(tenToPower(1 + rng.IntN(7)))
+		}
+		stones[i] = strconv.Itoa(stone)
+	}
+	return strings.Join(stones, " ") + "\n"
+}
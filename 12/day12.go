@@ -0,0 +1,137 @@
+// https://adventofcode.com/2024/day/12
+// aoc2024 run 12 --input 12/12.txt
+
+package day12
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/graph"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Garden struct {
+	puzzle string
+	plants *grid.Grid[byte]
+}
+
+// NewGarden parses the map of plants, each a letter
+func NewGarden(puzzle string) (*Garden, error) {
+	plants, err := grid.ParseFunc(puzzle, func(c byte) (byte, error) {
+		if (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') {
+			return 0, fmt.Errorf("expected a plant letter, got %q", c)
+		}
+		return c, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Garden{puzzle: puzzle, plants: plants}, nil
+}
+
+func (g *Garden) IsInBounds(pt grid.Point) bool {
+	return g.plants.InBounds(pt)
+}
+
+// GetPlant returns the plant at pt, or 0 if out-of-bounds
+func (g *Garden) GetPlant(pt grid.Point) byte {
+	return g.plants.GetOr(pt, 0)
+}
+
+func (g *Garden) View() string {
+	return grid.Text(g.plants)
+}
+
+func (g *Garden) IsSamePlant(otherPlant byte, pt grid.Point) bool {
+	thisPlant := g.GetPlant(pt)
+	return thisPlant == otherPlant
+}
+
+func (g *Garden) IsBoundary(otherPlant byte, pt grid.Point) bool {
+	return !g.IsSamePlant(otherPlant, pt)
+}
+
+func (g *Garden) GetCellMetric(pt grid.Point) RegionMetric {
+	if !g.IsInBounds(pt) {
+		return RegionMetric{}
+	}
+	metric := RegionMetric{Area: 1}
+	thisPlant := g.GetPlant(pt)
+	for _, dir := range grid.Dirs4 {
+		if g.IsBoundary(thisPlant, pt.Add(dir)) {
+			metric.Perimeter++
+		}
+	}
+	return metric
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type RegionMetric struct {
+	Area, Perimeter int
+}
+
+func (rm RegionMetric) Add(other RegionMetric) RegionMetric {
+	return RegionMetric{
+		Area:      rm.Area + other.Area,
+		Perimeter: rm.Perimeter + other.Perimeter,
+	}
+}
+
+func (rm RegionMetric) Cost() int {
+	return rm.Area * rm.Perimeter
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Regions lists the points of each region, a patch of the same plant
+func (g *Garden) Regions() [][]grid.Point {
+	return graph.Components(g.plants.Points(), graph.Grid4(g.plants, func(from, to grid.Point) bool {
+		return g.GetPlant(from) == g.GetPlant(to)
+	}))
+}
+
+func (g *Garden) TotalCost() int {
+	var totalCost int
+	for _, region := range g.Regions() {
+		// accumulate the metrics
+		var metric RegionMetric
+		for _, pt := range region {
+			metric = metric.Add(g.GetCellMetric(pt))
+		}
+		totalCost += metric.Cost()
+	}
+	return totalCost
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 12, New: func() aoc.Solver { return &solver{} }})
+}
+
+type solver struct {
+	aoc.Part1Only
+	garden *Garden
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.garden, err = NewGarden(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	aoc.Logger(ctx).Debug("garden", "plants", aoc.Lazy(s.garden.View))
+	aoc.Snapshot(ctx, "regions", s.garden.plants.Clone)
+	return s.garden.TotalCost(), nil
+}
@@ -0,0 +1,32 @@
+package day12
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 12) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 12, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 12, 2) }
+
+func TestTotalCostNotSquare(t *testing.T) {
+	garden, err := NewGarden("AAA\nABA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A is area 5 perimeter 12, B is area 1 perimeter 4
+	if got := garden.TotalCost(); got != 5*12+1*4 {
+		t.Errorf("TotalCost = %d, want %d", got, 5*12+1*4)
+	}
+}
+
+func FuzzNewGarden(f *testing.F) {
+	aoctest.Fuzz(f, 12, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) <= 0 {
+			t.Errorf("fencing cost %v is not positive", answer)
+		}
+	})
+}
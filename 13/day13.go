@@ -0,0 +1,373 @@
+// https://adventofcode.com/2024/day/13
+// aoc2024 run 13 --input 13/13.txt
+
+package day13
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/checked"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+// Rules are what pressing the buttons costs, and how far each part goes.
+type Rules struct {
+	CostA, CostB int // tokens per press of each button
+	MaxPresses   int // most each button may be pressed in part 1
+	Conversion   int // added to each prize coordinate in part 2
+}
+
+// DefaultRules are the puzzle's.
+var DefaultRules = Rules{CostA: 3, CostB: 1, MaxPresses: 100, Conversion: 10000000000000}
+
+type Point struct{ X, Y int }
+
+type ClawGame struct {
+	ButtonA Point
+	ButtonB Point
+	Prize   Point
+}
+
+var (
+	buttonARegexp = regexp.MustCompile(`^Button A: X\+(\d+), Y\+(\d+)$`)
+	buttonBRegexp = regexp.MustCompile(`^Button B: X\+(\d+), Y\+(\d+)$`)
+	prizeRegexp   = regexp.MustCompile(`^Prize: X=(\d+), Y=(\d+)$`)
+)
+
+// readPoint extracts the X,Y pair from line, which is at lineNum
+func readPoint(line string, lineNum int, re *regexp.Regexp, expected string) (Point, error) {
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return Point{}, parse.Errorf(lineNum, "expected '%s', got %q", expected, line)
+	}
+	x, err := parse.Int(lineNum, match[1])
+	if err != nil {
+		return Point{}, err
+	}
+	y, err := parse.Int(lineNum, match[2])
+	if err != nil {
+		return Point{}, err
+	}
+	return Point{x, y}, nil
+}
+
+// NewClawGames parses blank-line separated games of Button A, Button B and Prize lines
+func NewClawGames(puzzle string) ([]ClawGame, error) {
+	games := []ClawGame{}
+	sections, starts := parse.Sections(parse.Lines(puzzle))
+	for i, lines := range sections {
+		lineNum := starts[i] + 1
+		if len(lines) != 3 {
+			return nil, parse.Errorf(lineNum, "expected 3 lines per game, got %d", len(lines))
+		}
+		buttonA, err := readPoint(lines[0], lineNum, buttonARegexp, "Button A: X+<x>, Y+<y>")
+		if err != nil {
+			return nil, err
+		}
+		buttonB, err := readPoint(lines[1], lineNum+1, buttonBRegexp, "Button B: X+<x>, Y+<y>")
+		if err != nil {
+			return nil, err
+		}
+		prize, err := readPoint(lines[2], lineNum+2, prizeRegexp, "Prize: X=<x>, Y=<y>")
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, ClawGame{buttonA, buttonB, prize})
+	}
+	if len(games) == 0 {
+		return nil, errors.New("no claw games")
+	}
+	return games, nil
+}
+
+func (g ClawGame) String() string {
+	return fmt.Sprintf("{ A: %v, B: %v, P: %v", g.ButtonA, g.ButtonB, g.Prize)
+}
+
+// ApplyConversion adds conversion to the prize's coordinates, or returns
+// an error wrapping aoc.ErrOverflow if they outgrow an int
+func (g *ClawGame) ApplyConversion(conversion int) error {
+	var calc checked.Calc
+	prize := Point{calc.Add(g.Prize.X, conversion), calc.Add(g.Prize.Y, conversion)}
+	if calc.Overflow {
+		return fmt.Errorf("%w: converting prize %v", aoc.ErrOverflow, g.Prize)
+	}
+	g.Prize = prize
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Finds the cheapest play, pressing each button at most r.MaxPresses...
+// returns 0 if cannot win, or an error wrapping aoc.ErrOverflow if the
+// cheapest costs more than an int holds.  r.MaxPresses may be large, so it
+// gives up when ctx is done, even part way through a's presses.
+func (g ClawGame) CheapestPlayBrute(ctx context.Context, r Rules) (int, error) {
+	// brute force since small range
+	minScore, found, overflowed := 0, false, false
+	for a := 0; a <= r.MaxPresses; a++ {
+		for b := 0; b <= r.MaxPresses; b++ {
+			if b%4096 == 0 && ctx.Err() != nil {
+				return 0, ctx.Err()
+			}
+			// does this button combo win?  the buttons only move forward,
+			// so a claw past an int is past the prize too
+			var calc checked.Calc
+			clawPt := Point{
+				calc.Add(calc.Mul(g.ButtonA.X, a), calc.Mul(g.ButtonB.X, b)),
+				calc.Add(calc.Mul(g.ButtonA.Y, a), calc.Mul(g.ButtonB.Y, b))}
+			if calc.Overflow || clawPt != g.Prize {
+				continue
+			}
+			// yep, calculate cost and check if min
+			thisCost := calc.Add(calc.Mul(r.CostA, a), calc.Mul(r.CostB, b))
+			if calc.Overflow {
+				overflowed = true // dearer than any that fit
+				continue
+			}
+			if !found || thisCost < minScore {
+				minScore, found = thisCost, true
+			}
+		}
+	}
+	if !found && overflowed {
+		return 0, fmt.Errorf("%w: cost of winning game %v", aoc.ErrOverflow, g)
+	}
+	return minScore, nil
+}
+
+// CheapestPlayBruteBig is CheapestPlayBrute computed with math/big, so
+// never overflows
+func (g ClawGame) CheapestPlayBruteBig(ctx context.Context, r Rules) (*big.Int, error) {
+	Ax, Ay := big.NewInt(int64(g.ButtonA.X)), big.NewInt(int64(g.ButtonA.Y))
+	Bx, By := big.NewInt(int64(g.ButtonB.X)), big.NewInt(int64(g.ButtonB.Y))
+	Px, Py := big.NewInt(int64(g.Prize.X)), big.NewInt(int64(g.Prize.Y))
+	costA, costB := big.NewInt(int64(r.CostA)), big.NewInt(int64(r.CostB))
+	// sum is x*a + y*b
+	sum := func(x, a, y, b *big.Int) *big.Int {
+		return new(big.Int).Add(new(big.Int).Mul(x, a), new(big.Int).Mul(y, b))
+	}
+	var minScore *big.Int
+	for a := 0; a <= r.MaxPresses; a++ {
+		bigA := big.NewInt(int64(a))
+		for b := 0; b <= r.MaxPresses; b++ {
+			if b%4096 == 0 && ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			bigB := big.NewInt(int64(b))
+			if sum(Ax, bigA, Bx, bigB).Cmp(Px) != 0 || sum(Ay, bigA, By, bigB).Cmp(Py) != 0 {
+				continue
+			}
+			if thisCost := sum(costA, bigA, costB, bigB); minScore == nil || thisCost.Cmp(minScore) < 0 {
+				minScore = thisCost
+			}
+		}
+	}
+	if minScore == nil {
+		return new(big.Int), nil
+	}
+	return minScore, nil
+}
+
+// CheapestPlayLinear finds the cheapest play by solving for the presses,
+// with no limit on them.  Returns 0 if cannot win, or an error wrapping
+// aoc.ErrOverflow if the arithmetic outgrows an int.
+func (g ClawGame) CheapestPlayLinear(r Rules) (int, error) {
+	Ta, Tb, ok, err := g.linearPresses()
+	if err != nil || !ok {
+		return 0, err
+	}
+	var calc checked.Calc
+	cost := calc.Add(calc.Mul(r.CostA, Ta), calc.Mul(r.CostB, Tb))
+	if calc.Overflow {
+		return 0, fmt.Errorf("%w: cost of %d and %d presses", aoc.ErrOverflow, Ta, Tb)
+	}
+	return cost, nil
+}
+
+// linearPresses solves for the presses of each button that win; ok is
+// false if there is no unique winning play
+func (g ClawGame) linearPresses() (Ta, Tb int, ok bool, err error) {
+	// matrix:
+	//  |Ax Bx| |Ta| = |Px|
+	//  |Ay By| |Tb| = |Py|
+	Ax, Ay, Bx, By := g.ButtonA.X, g.ButtonA.Y, g.ButtonB.X, g.ButtonB.Y
+	var calc checked.Calc
+	det := calc.Sub(calc.Mul(Ax, By), calc.Mul(Ay, Bx))
+	if calc.Overflow {
+		return 0, 0, false, fmt.Errorf("%w: solving game %v", aoc.ErrOverflow, g)
+	}
+	if det == 0 {
+		return 0, 0, false, nil // no solution or we can't go backwards
+	}
+	// Cramer's rule
+	Px, Py := g.Prize.X, g.Prize.Y
+	Ta = calc.Sub(calc.Mul(Px, By), calc.Mul(Py, Bx)) / det
+	Tb = calc.Sub(calc.Mul(Py, Ax), calc.Mul(Px, Ay)) / det
+
+	// check if solution is valid
+	x := calc.Add(calc.Mul(Ta, Ax), calc.Mul(Tb, Bx))
+	y := calc.Add(calc.Mul(Ta, Ay), calc.Mul(Tb, By))
+	if calc.Overflow {
+		return 0, 0, false, fmt.Errorf("%w: solving game %v", aoc.ErrOverflow, g)
+	}
+	if x == Px && y == Py && Ta >= 0 && Tb >= 0 {
+		return Ta, Tb, true, nil
+	}
+	return 0, 0, false, nil
+}
+
+// CheapestPlayLinearBig is CheapestPlayLinear computed with math/big, so
+// never overflows, after adding conversion to the prize's coordinates
+func (g ClawGame) CheapestPlayLinearBig(r Rules, conversion int) *big.Int {
+	Ax, Ay := big.NewInt(int64(g.ButtonA.X)), big.NewInt(int64(g.ButtonA.Y))
+	Bx, By := big.NewInt(int64(g.ButtonB.X)), big.NewInt(int64(g.ButtonB.Y))
+	Px := new(big.Int).Add(big.NewInt(int64(g.Prize.X)), big.NewInt(int64(conversion)))
+	Py := new(big.Int).Add(big.NewInt(int64(g.Prize.Y)), big.NewInt(int64(conversion)))
+
+	// as linearPresses, but checking the divisions are exact instead of
+	// multiplying back
+	det := new(big.Int).Sub(new(big.Int).Mul(Ax, By), new(big.Int).Mul(Ay, Bx))
+	if det.Sign() == 0 {
+		return new(big.Int)
+	}
+	Ta, ra := new(big.Int).QuoRem(new(big.Int).Sub(new(big.Int).Mul(Px, By), new(big.Int).Mul(Py, Bx)), det, new(big.Int))
+	Tb, rb := new(big.Int).QuoRem(new(big.Int).Sub(new(big.Int).Mul(Py, Ax), new(big.Int).Mul(Px, Ay)), det, new(big.Int))
+	if ra.Sign() != 0 || rb.Sign() != 0 || Ta.Sign() < 0 || Tb.Sign() < 0 {
+		return new(big.Int)
+	}
+	cost := new(big.Int).Mul(big.NewInt(int64(r.CostA)), Ta)
+	return cost.Add(cost, new(big.Int).Mul(big.NewInt(int64(r.CostB)), Tb))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 13, New: func() aoc.Solver { return &solver{rules: DefaultRules} }, Generate: generate, Big: true})
+}
+
+type solver struct {
+	games []ClawGame
+	rules Rules
+}
+
+func (s *solver) DefineParams(fs *flag.FlagSet) {
+	fs.Var(countValue{&s.rules.CostA}, "cost-a", "tokens per press of button A")
+	fs.Var(countValue{&s.rules.CostB}, "cost-b", "tokens per press of button B")
+	fs.Var(countValue{&s.rules.MaxPresses}, "max-presses", "most each button may be pressed in part 1")
+	fs.IntVar(&s.rules.Conversion, "conversion", s.rules.Conversion, "added to each prize coordinate in part 2")
+}
+
+// countValue is a flag.Value for an int that can't be negative, like a
+// cost or a number of presses
+type countValue struct{ n *int }
+
+func (v countValue) String() string {
+	if v.n == nil {
+		return ""
+	}
+	return strconv.Itoa(*v.n)
+}
+
+func (v countValue) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return fmt.Errorf("bad count %q, expected 0 or more", s)
+	}
+	*v.n = n
+	return nil
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.games, err = NewClawGames(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	if aoc.Big(ctx) {
+		cost := new(big.Int)
+		for _, game := range s.games {
+			thisCost, err := game.CheapestPlayBruteBig(ctx, s.rules)
+			if err != nil {
+				return nil, err
+			}
+			cost.Add(cost, thisCost)
+		}
+		return cost, nil
+	}
+	cost := 0
+	for _, game := range s.games {
+		thisCost, err := game.CheapestPlayBrute(ctx, s.rules)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if cost, ok = checked.Add(cost, thisCost); !ok {
+			return nil, fmt.Errorf("%w: summing costs", aoc.ErrOverflow)
+		}
+	}
+	return cost, nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	if aoc.Big(ctx) {
+		cost := new(big.Int)
+		for _, game := range s.games {
+			cost.Add(cost, game.CheapestPlayLinearBig(s.rules, s.rules.Conversion))
+		}
+		return cost, nil
+	}
+	games := slices.Clone(s.games)
+	for i := 0; i < len(games); i++ {
+		if err := games[i].ApplyConversion(s.rules.Conversion); err != nil {
+			return nil, err
+		}
+	}
+	cost := 0
+	for _, game := range games {
+		thisCost, err := game.CheapestPlayLinear(s.rules)
+		if err != nil {
+			return nil, err
+		}
+		var ok bool
+		if cost, ok = checked.Add(cost, thisCost); !ok {
+			return nil, fmt.Errorf("%w: summing costs", aoc.ErrOverflow)
+		}
+	}
+	return cost, nil
+}
+
+// Verify checks the linear solution of each game against brute force, for
+// wins brute force can reach
+func (s *solver) Verify(ctx context.Context) error {
+	for i, game := range s.games {
+		fast, err := game.CheapestPlayLinear(s.rules)
+		if err != nil {
+			return err
+		}
+		naive, err := game.CheapestPlayBrute(ctx, s.rules)
+		if err != nil {
+			return err
+		}
+		if Ta, Tb, ok, _ := game.linearPresses(); ok && (Ta > s.rules.MaxPresses || Tb > s.rules.MaxPresses) {
+			fast = 0 // out of part 1's reach
+		}
+		if fast != naive {
+			return &aoc.Mismatch{What: fmt.Sprintf("game %d cost", i+1), Fast: fast, Naive: naive}
+		}
+	}
+	return nil
+}
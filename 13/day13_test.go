@@ -0,0 +1,138 @@
+package day13
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 13) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 13, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 13, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 13, 2) }
+
+func TestCheapestPlay(t *testing.T) {
+	tests := []struct {
+		game ClawGame
+		cost int
+	}{
+		{ClawGame{Point{94, 34}, Point{22, 67}, Point{8400, 5400}}, 280},
+		{ClawGame{Point{26, 66}, Point{67, 21}, Point{12748, 12176}}, 0},
+		{ClawGame{Point{17, 86}, Point{84, 37}, Point{7870, 6450}}, 200},
+		{ClawGame{Point{69, 23}, Point{27, 71}, Point{18641, 10279}}, 0},
+		{ClawGame{Point{1, 0}, Point{0, 1}, Point{-5, 3}}, 0}, // needs negative presses
+	}
+	for _, tt := range tests {
+		if got, err := tt.game.CheapestPlayBrute(context.Background(), DefaultRules); got != tt.cost || err != nil {
+			t.Errorf("CheapestPlayBrute(%v) = %d, %v, want %d", tt.game, got, err, tt.cost)
+		}
+		if got, err := tt.game.CheapestPlayBruteBig(context.Background(), DefaultRules); got.Int64() != int64(tt.cost) || err != nil {
+			t.Errorf("CheapestPlayBruteBig(%v) = %v, %v, want %d", tt.game, got, err, tt.cost)
+		}
+		if got, err := tt.game.CheapestPlayLinear(DefaultRules); got != tt.cost || err != nil {
+			t.Errorf("CheapestPlayLinear(%v) = %d, %v, want %d", tt.game, got, err, tt.cost)
+		}
+		if got := tt.game.CheapestPlayLinearBig(DefaultRules, 0); got.Int64() != int64(tt.cost) {
+			t.Errorf("CheapestPlayLinearBig(%v) = %v, want %d", tt.game, got, tt.cost)
+		}
+	}
+}
+
+func TestRulesParams(t *testing.T) {
+	d, _ := aoc.Lookup(13)
+	for _, name := range []string{"cost-a", "cost-b", "max-presses"} {
+		if _, err := d.NewSolver(aoc.Params{name: "-1"}); err == nil {
+			t.Errorf("%s=-1: want an error", name)
+		}
+	}
+
+	// pressing a billion times each is more than a moment's work
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	rules := DefaultRules
+	rules.MaxPresses = 1000000000
+	game := ClawGame{Point{94, 34}, Point{22, 67}, Point{8400, 5400}}
+	if _, err := game.CheapestPlayBrute(ctx, rules); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CheapestPlayBrute: got %v, want DeadlineExceeded", err)
+	}
+	if _, err := game.CheapestPlayBruteBig(ctx, rules); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("CheapestPlayBruteBig: got %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestCheapestPlayOverflow(t *testing.T) {
+	// a prize ~10^15 away, with the buttons' coordinates ~10^4, multiplies
+	// past 2^63 in Cramer's rule
+	const presses = 100000000000
+	game := ClawGame{Point{10007, 3}, Point{5, 10009}, Point{10012 * presses, 10012 * presses}}
+	if _, err := game.CheapestPlayLinear(DefaultRules); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("CheapestPlayLinear: got %v, want ErrOverflow", err)
+	}
+	if got := game.CheapestPlayLinearBig(DefaultRules, 0); got.Int64() != 4*presses {
+		t.Errorf("CheapestPlayLinearBig = %v, want %d", got, 4*presses)
+	}
+	if err := game.ApplyConversion(math.MaxInt); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("ApplyConversion: got %v, want ErrOverflow", err)
+	}
+
+	// pressing A 4 times moves the claw 2^64 across, which wraps to 0, so
+	// would seem to win with B
+	game = ClawGame{Point{1 << 62, 1}, Point{1, 1}, Point{1, 5}}
+	if got, err := game.CheapestPlayBrute(context.Background(), DefaultRules); got != 0 || err != nil {
+		t.Errorf("CheapestPlayBrute past an int = %d, %v, want 0", got, err)
+	}
+
+	// a win costing more than an int holds
+	rules := DefaultRules
+	rules.CostA = math.MaxInt
+	game = ClawGame{Point{1, 0}, Point{0, 1}, Point{2, 0}}
+	if _, err := game.CheapestPlayBrute(context.Background(), rules); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("CheapestPlayBrute: got %v, want ErrOverflow", err)
+	}
+	want := new(big.Int).Mul(big.NewInt(math.MaxInt), big.NewInt(2))
+	if got, err := game.CheapestPlayBruteBig(context.Background(), rules); err != nil || got.Cmp(want) != 0 {
+		t.Errorf("CheapestPlayBruteBig = %v, %v, want %v", got, err, want)
+	}
+
+	// part 2 converts the prize to twice as far
+	d, _ := aoc.Lookup(13)
+	prize := 10012*2*presses - DefaultRules.Conversion
+	input := fmt.Sprintf("Button A: X+10007, Y+3\nButton B: X+5, Y+10009\nPrize: X=%d, Y=%d\n", prize, prize)
+	if _, err := d.Solve(context.Background(), 2, input); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("Solve: got %v, want ErrOverflow", err)
+	}
+	if got, err := d.Solve(aoc.WithBig(context.Background()), 2, input); err != nil || fmt.Sprint(got) != fmt.Sprint(8*presses) {
+		t.Errorf("Solve with big: got %v, %v, want %d", got, err, 8*presses)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	d, _ := aoc.Lookup(13)
+	if err := d.Verify(context.Background(), "Button A: X+94, Y+34\nButton B: X+22, Y+67\nPrize: X=8400, Y=5400\n"); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+
+	// the linear solution gives up on parallel buttons, which the puzzle never has
+	var m *aoc.Mismatch
+	err := d.Verify(context.Background(), "Button A: X+2, Y+2\nButton B: X+1, Y+1\nPrize: X=4, Y=4\n")
+	if !errors.As(err, &m) || m.Fast != 0 || m.Naive != 4 {
+		t.Errorf("Verify parallel buttons: got %v, want a mismatch of 0 and 4", err)
+	}
+}
+
+func FuzzNewClawGames(f *testing.F) {
+	aoctest.Fuzz(f, 13, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: cost %v is negative", part, answer)
+		}
+	})
+}
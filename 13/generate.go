@@ -0,0 +1,29 @@
+package day13
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate makes size claw games, each with independent buttons as the
+// puzzle promises; about half are winnable within part 1's presses
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	games := make([]string, size)
+	for i := range games {
+		var g ClawGame
+		for g.ButtonA.X*g.ButtonB.Y == g.ButtonA.Y*g.ButtonB.X {
+			g.ButtonA = Point{10 + rng.IntN(90), 10 + rng.IntN(90)}
+			g.ButtonB = Point{10 + rng.IntN(90), 10 + rng.IntN(90)}
+		}
+		a, b := rng.IntN(DefaultRules.MaxPresses+1), rng.IntN(DefaultRules.MaxPresses+1)
+		g.Prize = Point{g.ButtonA.X*a + g.ButtonB.X*b, g.ButtonA.Y*a + g.ButtonB.Y*b}
+		if rng.IntN(2) == 0 {
+			g.Prize.X += 1 + rng.IntN(9)
+		}
+		games[i] = fmt.Sprintf("Button A: X+%d, Y+%d\nButton B: X+%d, Y+%d\nPrize: X=%d, Y=%d\n",
+			g.ButtonA.X, g.ButtonA.Y, g.ButtonB.X, g.ButtonB.Y, g.Prize.X, g.Prize.Y)
+	}
+	return strings.Join(games, "\n")
+}
@@ -0,0 +1,453 @@
+// https://adventofcode.com/2024/day/14
+// aoc2024 run 14 --input 14/14.txt
+
+package day14
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/export"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/replay"
+	"github.com/ollama/ollama/api"
+	ollama "github.com/ollama/ollama/api"
+)
+
+type Point struct{ X, Y int }
+
+type PointF64 struct{ X, Y float64 }
+
+type Robot struct {
+	Pos Point
+	Vel Point
+}
+
+var robotRegexp = regexp.MustCompile(`^p=(-?\d+),(-?\d+) v=(-?\d+),(-?\d+)$`)
+
+// NewRobots parses one robot per line, each of which must start in the room
+func NewRobots(puzzle string, room Point) ([]Robot, error) {
+	robots := []Robot{}
+	for i, line := range parse.Lines(puzzle) {
+		match := robotRegexp.FindStringSubmatch(line)
+		if match == nil {
+			return nil, parse.Errorf(i+1, "expected 'p=<x>,<y> v=<vx>,<vy>', got %q", line)
+		}
+		var nums [4]int
+		for j := range nums {
+			n, err := parse.Int(i+1, match[j+1])
+			if err != nil {
+				return nil, err
+			}
+			nums[j] = n
+		}
+		robot := Robot{Point{nums[0], nums[1]}, Point{nums[2], nums[3]}}
+		if robot.Pos.X < 0 || robot.Pos.X >= room.X || robot.Pos.Y < 0 || robot.Pos.Y >= room.Y {
+			return nil, parse.Errorf(i+1, "position %d,%d outside the %dx%d room", robot.Pos.X, robot.Pos.Y, room.X, room.Y)
+		}
+		robots = append(robots, robot)
+	}
+	if len(robots) == 0 {
+		return nil, errors.New("no robots")
+	}
+	return robots, nil
+}
+
+// mod is the non-negative remainder of a/m
+func mod(a, m int) int {
+	return ((a % m) + m) % m
+}
+
+func Operate(robots []Robot, roomSize Point, steps int) {
+	for step := 0; step < steps; step++ {
+		for i := 0; i < len(robots); i++ {
+			robot := &robots[i]
+			robot.Pos.X = mod(robot.Pos.X+robot.Vel.X, roomSize.X)
+			robot.Pos.Y = mod(robot.Pos.Y+robot.Vel.Y, roomSize.Y)
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+const UL, UR, LL, LR, NOPE = 1, 2, 3, 4, 0
+
+func QuandrantOf(p Point, roomSize Point) int {
+	if p.X < roomSize.X/2 && p.Y < roomSize.Y/2 {
+		return UL
+	} else if p.X > roomSize.X/2 && p.Y < roomSize.Y/2 {
+		return UR
+	} else if p.X < roomSize.X/2 && p.Y > roomSize.Y/2 {
+		return LL
+	} else if p.X > roomSize.X/2 && p.Y > roomSize.Y/2 {
+		return LR
+	}
+	return NOPE
+}
+
+// returns (UL, UR, LL, LR)
+func QuadrantScores(robots []Robot, roomSize Point) (ul int, ur int, ll int, lr int) {
+	scores := make([]int, 5)
+	for i := 0; i < len(robots); i++ {
+		robotPos := robots[i].Pos
+		quadrant := QuandrantOf(robotPos, roomSize)
+		scores[quadrant]++
+	}
+	return scores[UL], scores[UR], scores[LL], scores[LR]
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type RobotHeatMap struct {
+	Map      [][]int
+	RoomSize Point
+}
+
+func MakeRobotHeatMap(robots []Robot, roomSize Point) RobotHeatMap {
+	hm := RobotHeatMap{
+		Map:      make([][]int, roomSize.Y),
+		RoomSize: roomSize,
+	}
+	for y := 0; y < roomSize.Y; y++ {
+		hm.Map[y] = make([]int, roomSize.X)
+		for x := 0; x < roomSize.X; x++ {
+			hm.Map[y][x] = 0
+		}
+	}
+	for _, robot := range robots {
+		hm.Map[robot.Pos.Y][robot.Pos.X] += 1
+	}
+	return hm
+}
+
+func (hm RobotHeatMap) View() string {
+	var sb strings.Builder
+	for y := 0; y < hm.RoomSize.Y; y++ {
+		for x := 0; x < hm.RoomSize.X; x++ {
+			val := hm.Map[y][x] % 10
+			if val == 0 {
+				sb.WriteByte(' ')
+			} else {
+				sb.WriteString(strconv.Itoa(val))
+			}
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Grid draws the heat map as View does, a robot count per cell
+func (hm RobotHeatMap) Grid() *grid.Grid[byte] {
+	g := grid.New[byte](hm.RoomSize.X, hm.RoomSize.Y)
+	for pt := range g.Points() {
+		glyph := byte(' ')
+		if val := hm.Map[pt.Y][pt.X] % 10; val != 0 {
+			glyph = byte('0' + val)
+		}
+		g.Set(pt, glyph)
+	}
+	return g
+}
+
+// returns meanVal, centroid, variance
+func (hm RobotHeatMap) GetMetrics() (float64, PointF64, PointF64) {
+	// calculate centroid
+	var centroid PointF64
+	var meanVal, count float64
+	for y := 0; y < hm.RoomSize.Y; y++ {
+		for x := 0; x < hm.RoomSize.X; x++ {
+			val := float64(hm.Map[y][x])
+			if val == 0 {
+				continue
+			}
+			centroid.X += float64(x)
+			centroid.Y += float64(y)
+			meanVal += val
+			count++
+		}
+	}
+	centroid.X /= count
+	centroid.Y /= count
+	meanVal /= count
+
+	// calculate center of mass
+	var variance PointF64
+	for y := 0; y < hm.RoomSize.Y; y++ {
+		for x := 0; x < hm.RoomSize.X; x++ {
+			val := float64(hm.Map[y][x])
+			if val == 0 {
+				continue
+			}
+			dx := val * (float64(x) - centroid.X)
+			dy := val * (float64(y) - centroid.Y)
+			variance.X += dx * dx
+			variance.Y += dy * dy
+		}
+	}
+	variance.X = math.Sqrt(variance.X)
+	variance.Y = math.Sqrt(variance.Y)
+
+	return meanVal, centroid, variance
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// treePalette draws robots bright on black, for the vision model
+var treePalette = func() export.Palette {
+	p := export.Palette{Background: color.RGBA{A: 255}, Colors: map[byte]color.RGBA{}}
+	for count := byte('1'); count <= '9'; count++ {
+		p.Colors[count] = color.RGBA{R: 80, G: 250, B: 123, A: 255}
+	}
+	return p
+}()
+
+// DoesOllamaThinkTheresAChristmasTrees asks a local vision model whether
+// the robots draw a tree, returning its response and whether it says YES.
+// It is an error if Ollama can't be asked, e.g. isn't running.
+func DoesOllamaThinkTheresAChristmasTrees(ctx context.Context, hm RobotHeatMap) (string, bool, error) {
+	var pngBuf bytes.Buffer
+	if err := export.PNG(&pngBuf, hm.Grid(), treePalette, 4); err != nil {
+		return "", false, err
+	}
+
+	ollamaURL, err := url.Parse("http://localhost:11434")
+	if err != nil {
+		return "", false, err
+	}
+
+	systemPrompt := `"Does the supplied image contain framed image of the outline of an evergreen tree?" 
+	Include YES or NO and a brief reason why.  You must be absolutely sure, you cannot be wrong about this. 
+	Christmas trees are evergreen trees and may have lights and ornaments but that is not necessary.`
+
+	ollamaClient := ollama.NewClient(ollamaURL, http.DefaultClient)
+	req := &ollama.GenerateRequest{
+		Model:  "llama3.2-vision:11b-instruct-q8_0", //"llama3.2-vision",
+		Prompt: "does the image contain the shape of an evergreen christmas tree?",
+		System: systemPrompt,
+		Images: []api.ImageData{pngBuf.Bytes()},
+	}
+
+	var sb strings.Builder
+	respFunc := func(resp ollama.GenerateResponse) error {
+		sb.WriteString(resp.Response)
+		return nil
+	}
+
+	err = ollamaClient.Generate(ctx, req, respFunc)
+	if err != nil {
+		return "", false, fmt.Errorf("asking ollama: %w", err)
+	}
+
+	response := sb.String()
+	if strings.Contains(strings.ToUpper(response), "YES") {
+		return response, true, nil
+	}
+	return response, false, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 14, New: func() aoc.Solver { return newSolver() }, Interactive: ollamaSearch, Generate: generate, Replay: replaySwarm})
+}
+
+// DefaultRoom is the size of the real puzzle's room; the example's is 11x7
+var DefaultRoom = Point{101, 103}
+
+// treeSpread is the most the robots may spread along each axis to look like
+// a tree
+const treeSpread = 450 // emperically determined
+
+type solver struct {
+	robots []Robot
+	room   Point   // size of the room
+	steps  int     // seconds the robots move for in part 1
+	spread float64 // the tree threshold, as treeSpread
+}
+
+func newSolver() *solver {
+	return &solver{room: DefaultRoom, steps: 100, spread: treeSpread}
+}
+
+// configured returns a solver with ctx's params, for the modes besides
+// solving: generating, replaying and asking Ollama
+func configured(ctx context.Context) (*solver, error) {
+	s := newSolver()
+	if err := aoc.Configure(ctx, 14, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *solver) DefineParams(fs *flag.FlagSet) {
+	fs.Var(pointValue{&s.room}, "room", "room `size`, as WIDTHxHEIGHT")
+	fs.IntVar(&s.steps, "steps", s.steps, "seconds the robots move for in part 1")
+	fs.Float64Var(&s.spread, "spread", s.spread, "most the robots may spread along each axis to look like a tree")
+}
+
+// pointValue is a flag.Value for a Point written as "XxY", e.g. "11x7"
+type pointValue struct{ p *Point }
+
+func (v pointValue) String() string {
+	if v.p == nil {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", v.p.X, v.p.Y)
+}
+
+func (v pointValue) Set(s string) error {
+	xs, ys, ok := strings.Cut(s, "x")
+	x, errX := strconv.Atoi(xs)
+	y, errY := strconv.Atoi(ys)
+	if !ok || errX != nil || errY != nil || x <= 0 || y <= 0 {
+		return fmt.Errorf("bad size %q, expected e.g. 11x7", s)
+	}
+	*v.p = Point{x, y}
+	return nil
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.robots, err = NewRobots(input, s.room)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	robots := slices.Clone(s.robots)
+	Operate(robots, s.room, s.steps)
+	ul, ur, ll, lr := QuadrantScores(robots, s.room)
+	log := aoc.Logger(ctx)
+	log.Debug("robots moved", "seconds", s.steps, "room", aoc.Lazy(func() string { return MakeRobotHeatMap(robots, s.room).View() }))
+	log.Info("quadrants", "ul", ul, "ur", ur, "ll", ll, "lr", lr)
+	aoc.Snapshot(ctx, "robots", MakeRobotHeatMap(robots, s.room).Grid)
+
+	safetyFactor := ul * ur * ll * lr
+	return safetyFactor, nil
+}
+
+// looksLikeTree is whether the robots are clustered enough to draw the tree,
+// spreading less than spread along each axis
+func looksLikeTree(hm RobotHeatMap, spread float64) bool {
+	_, _, stddev := hm.GetMetrics()
+	return stddev.X < spread && stddev.Y < spread
+}
+
+// stepsToTree operates the robots until they cluster, returning the step count.
+// There may be no tree at all, so it gives up when ctx is done.
+func stepsToTree(ctx context.Context, robots []Robot, room Point, spread float64) (int, error) {
+	stepsToTree := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		if looksLikeTree(MakeRobotHeatMap(robots, room), spread) {
+			break
+		}
+		Operate(robots, room, 1)
+		stepsToTree++
+	}
+	return stepsToTree, nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	robots := slices.Clone(s.robots)
+	steps, err := stepsToTree(ctx, robots, s.room, s.spread)
+	if err != nil {
+		return nil, err
+	}
+	aoc.Logger(ctx).Debug("tree", "seconds", steps, "room", aoc.Lazy(func() string { return MakeRobotHeatMap(robots, s.room).View() }))
+	aoc.Snapshot(ctx, "tree", MakeRobotHeatMap(robots, s.room).Grid)
+	return steps, nil
+}
+
+// replaySwarm records the robots moving until they draw the tree, or
+// until they are back where they started if they never do
+func replaySwarm(ctx context.Context, input string, rec *replay.Recorder) error {
+	s, err := configured(ctx)
+	if err != nil {
+		return err
+	}
+	robots, err := NewRobots(input, s.room)
+	if err != nil {
+		return err
+	}
+	period := s.room.X * s.room.Y
+	steps, tree := 0, false
+	for ; steps < period; steps++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hm := MakeRobotHeatMap(robots, s.room)
+		rec.Record(hm.Grid(), fmt.Sprintf("step %d", steps))
+		if looksLikeTree(hm, s.spread) {
+			tree = true
+			break
+		}
+		Operate(robots, s.room, 1)
+	}
+	outcome := fmt.Sprintf("no tree in %d steps", steps)
+	if tree {
+		outcome = fmt.Sprintf("tree at step %d", steps)
+	}
+	rec.Done(MakeRobotHeatMap(robots, s.room).Grid(), outcome)
+	return nil
+}
+
+// ollamaSearch is part 2 Ollama-version, asking a local vision model.
+// Each candidate is also sent as a snapshot, e.g. for --export-dir.
+func ollamaSearch(ctx context.Context, input string) error {
+	s, err := configured(ctx)
+	if err != nil {
+		return err
+	}
+	robots, err := NewRobots(input, s.room)
+	if err != nil {
+		return err
+	}
+	// CHEATING!  =) Stepping forward a bunch since we knew answer above
+	llmStepsToTree, err := stepsToTree(ctx, slices.Clone(robots), s.room, s.spread)
+	if err != nil {
+		return err
+	}
+	llmStepsToTree -= 3
+	Operate(robots, s.room, llmStepsToTree)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		hm := MakeRobotHeatMap(robots, s.room)
+		aoc.Snapshot(ctx, fmt.Sprintf("step%d", llmStepsToTree), hm.Grid)
+
+		start := time.Now()
+		response, isTree, err := DoesOllamaThinkTheresAChristmasTrees(ctx, hm)
+		if err != nil {
+			return err
+		}
+		aoc.Logger(ctx).Info("ollama", "step", llmStepsToTree, "tree", isTree, "elapsed", time.Since(start), "response", response)
+		if isTree {
+			break
+		}
+		Operate(robots, s.room, 1)
+		llmStepsToTree++
+	}
+	fmt.Println("14.2llm:", llmStepsToTree)
+	return nil
+}
@@ -0,0 +1,85 @@
+package day14
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 14) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 14, 20) }
+
+func TestReplay(t *testing.T) { aoctest.Replay(t, 14) }
+
+// The example has no tree, so the real puzzle input is needed to benchmark part 2.
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 14, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 14, 2) }
+
+func TestQuadrantScores(t *testing.T) {
+	data, err := os.ReadFile("14.test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	exampleRoom := Point{11, 7}
+	robots, err := NewRobots(string(data), exampleRoom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	Operate(robots, exampleRoom, 100)
+	ul, ur, ll, lr := QuadrantScores(robots, exampleRoom)
+	if got := ul * ur * ll * lr; got != 12 {
+		t.Errorf("safety factor = %d, want 12", got)
+	}
+}
+
+func TestOperateWraps(t *testing.T) {
+	robots := []Robot{{Pos: Point{2, 4}, Vel: Point{2, -3}}}
+	Operate(robots, Point{11, 7}, 5)
+	if want := (Point{1, 3}); robots[0].Pos != want {
+		t.Errorf("Pos = %v, want %v", robots[0].Pos, want)
+	}
+
+	// landing on a multiple of the room size, from either side, is 0
+	robots = []Robot{{Pos: Point{1, 1}, Vel: Point{-12, 6}}, {Pos: Point{10, 6}, Vel: Point{1, 1}}}
+	Operate(robots, Point{11, 7}, 1)
+	for _, robot := range robots {
+		if want := (Point{0, 0}); robot.Pos != want {
+			t.Errorf("Pos = %v, want %v", robot.Pos, want)
+		}
+	}
+}
+
+func TestNewRobotsOutsideRoom(t *testing.T) {
+	room := Point{11, 7}
+	for _, line := range []string{"p=-101,0 v=0,0", "p=11,0 v=1,1", "p=0,7 v=1,1"} {
+		var perr *parse.Error
+		if _, err := NewRobots("p=0,0 v=1,1\n"+line+"\n", room); !errors.As(err, &perr) || perr.Line != 2 {
+			t.Errorf("NewRobots(%q) = %v, want a parse error on line 2", line, err)
+		}
+	}
+	if _, err := NewRobots("p=10,6 v=0,0\n", room); err != nil {
+		t.Errorf("NewRobots in the corner: %v", err)
+	}
+}
+
+func TestOllamaSearchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := ollamaSearch(ctx, "p=0,4 v=3,-3\n"); !errors.Is(err, context.Canceled) {
+		t.Errorf("ollamaSearch: got %v, want context.Canceled", err)
+	}
+}
+
+func FuzzNewRobots(f *testing.F) {
+	aoctest.Fuzz(f, 14, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: %v is negative", part, answer)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+package day14
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate makes size robots.  Most of them are planted to huddle in the
+// middle of the room at some step, so there is always a "tree" to find;
+// the rest are scattered, but few enough not to hide it.
+func generate(ctx context.Context, rng *rand.Rand, size int) string {
+	s, err := configured(ctx)
+	if err != nil {
+		panic(err) // the params are checked before generating
+	}
+	room := s.room
+	treeStep := rng.IntN(10000)
+	scattered := min(size*3/10, 150)
+	var sb strings.Builder
+	for i := 0; i < size; i++ {
+		robot := Robot{
+			Pos: Point{rng.IntN(room.X), rng.IntN(room.Y)},
+			Vel: Point{rng.IntN(199) - 99, rng.IntN(199) - 99},
+		}
+		if i >= scattered {
+			// place it in the huddle at treeStep, then wind back to step 0
+			huddle := Point{
+				mod(room.X/2-7+rng.IntN(15), room.X),
+				mod(room.Y/2-7+rng.IntN(15), room.Y),
+			}
+			robot.Pos = Point{
+				mod(huddle.X-treeStep*robot.Vel.X, room.X),
+				mod(huddle.Y-treeStep*robot.Vel.Y, room.Y),
+			}
+		}
+		fmt.Fprintf(&sb, "p=%d,%d v=%d,%d\n", robot.Pos.X, robot.Pos.Y, robot.Vel.X, robot.Vel.Y)
+	}
+	return sb.String()
+}
@@ -0,0 +1,349 @@
+// https://adventofcode.com/2024/day/15
+// aoc2024 run 15 --input 15/15.txt
+
+package day15
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+const (
+	Empty = '.'
+	Box   = 'O'
+	Bot   = '@'
+	Wall  = '#'
+	LBox  = '['
+	RBox  = ']'
+
+	Up      = '^'
+	Down    = 'v'
+	Left    = '<'
+	Right   = '>'
+	Newline = '\n'
+)
+
+type Point = grid.Point
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Warehouse struct {
+	Map      *grid.Grid[byte]
+	Moves    []byte
+	RobotPos Point
+
+	OnStep func(move int) bool // if set, called after each move Operate makes, e.g. to record it; false stops Operate
+}
+
+// NewWarehouse parses the warehouse map, a blank line, then the robot's moves
+func NewWarehouse(puzzle string) (*Warehouse, error) {
+	// split puzzle parts
+	sections, starts := parse.Sections(parse.Lines(puzzle))
+	if len(sections) != 2 {
+		return nil, fmt.Errorf("expected a map and moves separated by a blank line, got %d sections", len(sections))
+	}
+	maze, moves := sections[0], sections[1]
+
+	warehouseMap, err := grid.ParseOnly(strings.Join(maze, "\n"), string([]byte{Empty, Box, Bot, Wall, LBox, RBox}))
+	if err != nil {
+		return nil, parse.Offset(err, starts[0])
+	}
+	robots := warehouseMap.FindAll(func(c byte) bool { return c == Bot })
+	if len(robots) != 1 {
+		return nil, parse.Errorf(starts[0]+1, "expected one robot, found %d", len(robots))
+	}
+
+	warehouse := &Warehouse{
+		Map:      warehouseMap,
+		RobotPos: robots[0],
+	}
+	for i, line := range moves {
+		for j, c := range []byte(line) {
+			if c != Up && c != Down && c != Left && c != Right {
+				return nil, parse.ErrorfAt(starts[1]+i+1, j+1, "expected a move, got %q", c)
+			}
+		}
+		warehouse.Moves = append(warehouse.Moves, line...)
+	}
+	return warehouse, nil
+}
+
+// Clone returns a copy of the warehouse, to operate without disturbing it
+func (w *Warehouse) Clone() *Warehouse {
+	return &Warehouse{
+		Map:      w.Map.Clone(),
+		Moves:    w.Moves, // read-only
+		RobotPos: w.RobotPos,
+	}
+}
+
+func (w *Warehouse) View() string {
+	return grid.Text(w.Map)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (w *Warehouse) IsInBounds(pt Point) bool {
+	return w.Map.InBounds(pt)
+}
+
+// GetCell returns the cell at pt; out-of-bounds is a Wall
+func (w *Warehouse) GetCell(pt Point) byte {
+	return w.Map.GetOr(pt, Wall)
+}
+
+func (w *Warehouse) SlideBox(boxPos Point, dir Point) bool {
+	nextPos := boxPos.Add(dir)
+	sideCell := w.GetCell(nextPos)
+	if sideCell == Wall {
+		return false
+	} else if sideCell == Box {
+		if !w.SlideBox(nextPos, dir) {
+			return false
+		}
+	}
+
+	w.Map.Set(boxPos, Empty)
+	w.Map.Set(nextPos, Box)
+	return true
+}
+
+// Moves the robot to the next position, according to rules
+// Returns true if the robot can move, false otherwise
+func (w *Warehouse) MoveRobot(dir Point) bool {
+	// check next position
+	nextPos := w.RobotPos.Add(dir)
+	sideCell := w.GetCell(nextPos)
+	if sideCell == Wall {
+		return false
+	} else if sideCell == Box {
+		// check if the box can move
+		if !w.SlideBox(nextPos, dir) {
+			// could not move box, so don't move bot
+			return false
+		}
+	} else if sideCell == LBox || sideCell == RBox {
+		if !w.SlideExpandedBox(nextPos, dir) {
+			// could not move box, so don't move bot
+			return false
+		}
+	}
+
+	w.Map.Set(w.RobotPos, Empty)
+	w.Map.Set(nextPos, Bot)
+	w.RobotPos = nextPos
+	return true
+}
+
+func (w *Warehouse) Operate() {
+	// Runs all the robot moves
+	for i, move := range w.Moves {
+		switch move {
+		case Newline:
+			continue
+		case Up:
+			w.MoveRobot(grid.Up)
+		case Down:
+			w.MoveRobot(grid.Down)
+		case Left:
+			w.MoveRobot(grid.Left)
+		case Right:
+			w.MoveRobot(grid.Right)
+		}
+		if w.OnStep != nil && !w.OnStep(i) {
+			return
+		}
+	}
+}
+
+// The GPS coordinate of a box is equal to 100 times its distance from
+// the top edge of the map plus its distance from the left edge of the map.
+func (w *Warehouse) GPSScore() int {
+	score := 0
+	for pt, cell := range w.Map.All() {
+		if cell == Box || cell == LBox {
+			score += 100*pt.Y + pt.X
+		}
+	}
+	return score
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (w *Warehouse) Expand() {
+	newMap := grid.New[byte](2*w.Map.Width(), w.Map.Height())
+	for pt, c := range w.Map.All() {
+		c1, c2 := c, c
+		if c == Box {
+			c1, c2 = LBox, RBox
+		} else if c == Bot {
+			c1, c2 = Bot, Empty
+			w.RobotPos = pt
+		}
+		newMap.Set(Point{X: 2 * pt.X, Y: pt.Y}, c1)
+		newMap.Set(Point{X: 2*pt.X + 1, Y: pt.Y}, c2)
+	}
+	w.Map = newMap
+	w.RobotPos.X *= 2
+}
+
+func (w *Warehouse) TryMoveBoxVertically(boxPos Point, dir Point) bool {
+	boxCell := w.GetCell(boxPos)
+	if boxCell == Empty {
+		return true
+	} else if boxCell == Wall {
+		return false
+	}
+
+	var otherBoxPos Point
+	if boxCell == LBox {
+		otherBoxPos = boxPos.Add(grid.Right)
+	} else { // boxCell == RBox
+		otherBoxPos = boxPos.Add(grid.Left)
+	}
+
+	nextPos, otherNextPos := boxPos.Add(dir), otherBoxPos.Add(dir)
+	nextCell, otherNextCell := w.GetCell(nextPos), w.GetCell(otherNextPos)
+	if nextCell == Wall || otherNextCell == Wall {
+		return false
+	}
+	if (nextCell == Empty || w.TryMoveBoxVertically(nextPos, dir)) &&
+		(otherNextCell == Empty || w.TryMoveBoxVertically(otherNextPos, dir)) {
+		return true
+	}
+
+	return false
+}
+
+// we call this when we know the box can move
+func (w *Warehouse) MoveBoxVertically(boxPos Point, dir Point) bool {
+	boxCell := w.GetCell(boxPos)
+	if boxCell == Empty {
+		return true
+	} else if boxCell == Wall {
+		return false
+	}
+
+	var otherBoxPos Point
+	var otherBoxCell byte
+	if boxCell == LBox {
+		otherBoxCell = RBox
+		otherBoxPos = boxPos.Add(grid.Right)
+	} else { // boxCell == RBox
+		otherBoxCell = LBox
+		otherBoxPos = boxPos.Add(grid.Left)
+	}
+
+	nextPos, otherNextPos := boxPos.Add(dir), otherBoxPos.Add(dir)
+	nextCell, otherNextCell := w.GetCell(nextPos), w.GetCell(otherNextPos)
+	if nextCell == Wall || otherNextCell == Wall {
+		return false
+	}
+	if nextCell == LBox || nextCell == RBox {
+		w.MoveBoxVertically(nextPos, dir)
+	}
+	if otherNextCell == LBox || otherNextCell == RBox {
+		w.MoveBoxVertically(otherNextPos, dir)
+	}
+	w.Map.Set(nextPos, boxCell)
+	w.Map.Set(otherNextPos, otherBoxCell)
+	w.Map.Set(boxPos, Empty)
+	w.Map.Set(otherBoxPos, Empty)
+	return false
+}
+
+func (w *Warehouse) SlideExpandedBox(boxPos Point, dir Point) bool {
+	// moving left/right
+	if dir.X != 0 {
+		nextPos := boxPos.Add(dir)
+		nextNextPos := nextPos.Add(dir)
+		nextNextCell := w.GetCell(nextNextPos)
+		if nextNextCell == Wall {
+			return false
+		}
+		if (nextNextCell == LBox || nextNextCell == RBox) &&
+			!w.SlideExpandedBox(nextNextPos, dir) {
+			return false
+		}
+
+		thisCell := w.GetCell(boxPos)
+		sideCell := w.GetCell(nextPos)
+		w.Map.Set(boxPos, Empty)
+		w.Map.Set(nextPos, thisCell)
+		w.Map.Set(nextNextPos, sideCell)
+		return true
+	}
+
+	// moving up/down
+	if w.TryMoveBoxVertically(boxPos, dir) {
+		w.MoveBoxVertically(boxPos, dir)
+		return true
+	}
+	return false
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 15, New: func() aoc.Solver { return &solver{} }, Generate: generate, Replay: replayOperate})
+}
+
+type solver struct {
+	warehouse *Warehouse
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.warehouse, err = NewWarehouse(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	warehouse := s.warehouse.Clone()
+	log := aoc.Logger(ctx)
+	log.Debug("warehouse", "map", aoc.Lazy(warehouse.View))
+	warehouse.Operate()
+	log.Debug("robot done", "map", aoc.Lazy(warehouse.View))
+	aoc.Snapshot(ctx, "warehouse", warehouse.Map.Clone)
+	return warehouse.GPSScore(), nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	warehouse := s.warehouse.Clone()
+	log := aoc.Logger(ctx)
+	warehouse.Expand()
+	log.Debug("wide warehouse", "map", aoc.Lazy(warehouse.View))
+	warehouse.Operate()
+	log.Debug("robot done", "map", aoc.Lazy(warehouse.View))
+	aoc.Snapshot(ctx, "warehouse", warehouse.Map.Clone)
+	return warehouse.GPSScore(), nil
+}
+
+// replayOperate records the robot's moves around the warehouse
+func replayOperate(ctx context.Context, input string, rec *replay.Recorder) error {
+	warehouse, err := NewWarehouse(input)
+	if err != nil {
+		return err
+	}
+	rec.Record(warehouse.Map, "start")
+	warehouse.OnStep = func(move int) bool {
+		rec.Record(warehouse.Map, fmt.Sprintf("move %d of %d: %c", move+1, len(warehouse.Moves), warehouse.Moves[move]))
+		return ctx.Err() == nil
+	}
+	warehouse.Operate()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rec.Done(warehouse.Map, fmt.Sprintf("GPS score %d", warehouse.GPSScore()))
+	return nil
+}
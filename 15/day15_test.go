@@ -0,0 +1,66 @@
+package day15
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 15) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 15, 20) }
+
+func TestReplay(t *testing.T) {
+	frames := aoctest.Replay(t, 15)
+	if got := frames[len(frames)-1].Caption; got != "GPS score 10092" {
+		t.Errorf("final caption %q", got)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 15, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 15, 2) }
+
+func TestExpandedPush(t *testing.T) {
+	w, err := NewWarehouse("#######\n#...#.#\n#.....#\n#..OO@#\n#..O..#\n#.....#\n#######\n\n<vv<<^^<<^^")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Expand()
+	w.Operate()
+	want := "##############\n##...[].##..##\n##...@.[]...##\n##....[]....##\n##..........##\n##..........##\n##############\n"
+	if got := w.View(); got != want {
+		t.Errorf("View =\n%s\nwant\n%s", got, want)
+	}
+	if got := w.GPSScore(); got != 105+207+306 {
+		t.Errorf("GPSScore = %d, want %d", got, 105+207+306)
+	}
+}
+
+func FuzzNewWarehouse(f *testing.F) {
+	aoctest.Fuzz(f, 15, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: GPS score %v is negative", part, answer)
+		}
+	})
+}
+
+// operating moves boxes around, but never loses or makes one
+func FuzzOperate(f *testing.F) {
+	aoctest.Seed(f, 15)
+	f.Fuzz(func(t *testing.T, input string) {
+		w, err := NewWarehouse(input)
+		if err != nil {
+			return
+		}
+		isBox := func(c byte) bool { return c == Box || c == LBox }
+		boxes := w.Map.Count(isBox)
+		w.Operate()
+		if got := w.Map.Count(isBox); got != boxes {
+			t.Errorf("operating left %d boxes, want %d", got, boxes)
+		}
+		if got := w.Map.Count(func(c byte) bool { return c == Bot }); got != 1 {
+			t.Errorf("operating left %d robots", got)
+		}
+	})
+}
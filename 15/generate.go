@@ -0,0 +1,41 @@
+package day15
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// generate makes a size x size walled warehouse of boxes and a few walls,
+// with one robot, then 10*size moves in lines of 70
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	size = max(size, 3)
+	g := grid.New[byte](size, size)
+	for pt := range g.Points() {
+		switch {
+		case pt.X == 0 || pt.Y == 0 || pt.X == size-1 || pt.Y == size-1:
+			g.Set(pt, Wall)
+		case rng.IntN(20) == 0:
+			g.Set(pt, Wall)
+		case rng.IntN(4) == 0:
+			g.Set(pt, Box)
+		default:
+			g.Set(pt, Empty)
+		}
+	}
+	g.Set(Point{X: 1 + rng.IntN(size-2), Y: 1 + rng.IntN(size-2)}, Bot)
+
+	var sb strings.Builder
+	sb.WriteString(grid.Text(g))
+	sb.WriteByte('\n')
+	moves := []byte{Up, Down, Left, Right}
+	for i := 0; i < 10*size; i++ {
+		sb.WriteByte(moves[rng.IntN(len(moves))])
+		if i%70 == 69 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
@@ -1,13 +1,16 @@
 // https://adventofcode.com/2024/day/17
-// go run 17/main.go 17/17.txt
+// aoc2024 run 17 --input 17/17.txt
 
-package main
+package day17
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"math"
-	"os"
+	"math/big"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -15,13 +18,16 @@ import (
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/checked"
+	"github.com/neomantra/aoc2024/parse"
 )
 
 func WithCommas(nums []int) string {
 	var str string
 	for i, n := range nums {
-		str += string(n + 48)
-		if i != len(str)-1 {
+		str += strconv.Itoa(n)
+		if i != len(nums)-1 {
 			str += ","
 		}
 	}
@@ -38,33 +44,41 @@ type Machine struct {
 	StartA  int
 }
 
-func NewMachine(puzzle string) *Machine {
-	// split puzzle parts
-	parts := strings.Split(puzzle, "\n\n")
-	registers, program := parts[0], parts[1][:]
+var (
+	registerRegexp = regexp.MustCompile(`^Register ([ABC]): (\d+)$`)
+	programRegexp  = regexp.MustCompile(`^Program: ([0-7](?:,[0-7])*)$`)
+)
 
-	var regRegexp = regexp.MustCompile(`\D*(\d*)\D*(\d*)\D*(\d*)`)
-	match := regRegexp.FindStringSubmatch(registers)
-	if len(match) != 4 {
-		return nil
+// NewMachine parses the A, B and C registers, a blank line, then the program
+func NewMachine(puzzle string) (*Machine, error) {
+	lines := parse.Lines(puzzle)
+	if len(lines) != 5 || lines[3] != "" {
+		return nil, fmt.Errorf("expected 3 register lines, a blank line and a program line")
 	}
 
 	machine := Machine{}
-	machine.A, _ = strconv.Atoi(match[1])
-	machine.B, _ = strconv.Atoi(match[2])
-	machine.C, _ = strconv.Atoi(match[3])
+	for i, reg := range []*int{&machine.A, &machine.B, &machine.C} {
+		match := registerRegexp.FindStringSubmatch(lines[i])
+		if match == nil || match[1] != string(rune('A'+i)) {
+			return nil, parse.Errorf(i+1, "expected 'Register %c: <value>', got %q", 'A'+i, lines[i])
+		}
+		val, err := parse.Int(i+1, match[2])
+		if err != nil {
+			return nil, err
+		}
+		*reg = val
+	}
 	machine.I = 0
 	machine.StartA = machine.A
 
-	codes := strings.Split(program, ": ")
-	if len(codes) != 2 {
-		return nil
+	match := programRegexp.FindStringSubmatch(lines[4])
+	if match == nil {
+		return nil, parse.Errorf(5, "expected 'Program: <3-bit codes>', got %q", lines[4])
 	}
-	for _, c := range strings.Split(codes[1], ",") {
-		n, _ := strconv.Atoi(c)
-		machine.Program = append(machine.Program, n)
+	for _, c := range strings.Split(match[1], ",") {
+		machine.Program = append(machine.Program, int(c[0]-'0'))
 	}
-	return &machine
+	return &machine, nil
 }
 
 func (m *Machine) Clone() *Machine {
@@ -195,7 +209,7 @@ func (m *Machine) Step() bool {
 	incrI := 2
 	switch opCode {
 	case 0: // adv
-		m.A = m.A / (1 << combo) // 2^combo
+		m.A = m.A >> combo // A / 2^combo, which is 0 rather than a panic past 63
 	case 1: // bxl
 		m.B = m.B ^ literal
 	case 2: // bst
@@ -210,9 +224,9 @@ func (m *Machine) Step() bool {
 	case 5: // out
 		m.Output = append(m.Output, combo%8)
 	case 6: // bdv
-		m.B = m.A / (1 << combo) // 2^combo
+		m.B = m.A >> combo // A / 2^combo, which is 0 rather than a panic past 63
 	case 7: // cdv
-		m.C = m.A / (1 << combo) // 2^combo
+		m.C = m.A >> combo // A / 2^combo, which is 0 rather than a panic past 63
 	default:
 		return false // invalid opcde
 	}
@@ -221,34 +235,52 @@ func (m *Machine) Step() bool {
 	return true
 }
 
-func (m *Machine) Run() {
-	for m.Step() {
+// Run steps the machine until it halts, or ctx is done
+func (m *Machine) Run(ctx context.Context) error {
+	for steps := 1; m.Step(); steps++ {
+		if steps%4096 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
 	}
+	return nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 
-// search backwards for quine possiblities
-func (m *Machine) QuineSearch() int {
+// search backwards for quine possiblities.  Returns an error wrapping
+// aoc.ErrOverflow if every possibility outgrows an int.
+func (m *Machine) QuineSearch(ctx context.Context) (int, error) {
 	// we go backwards
 	mods := []int{0, 1, 2, 3, 4, 5, 6, 7}
 	alist := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	overflowed := false
 	for i := len(m.Program) - 1; i >= 0; i-- {
 		target := m.Program[i]
 		var newAlist []int
 		for _, a := range alist {
+			shifted, ok := checked.Shl(a, 3)
+			if !ok {
+				// any A from here on is larger than those that fit
+				overflowed = true
+				continue
+			}
 			for _, mod := range mods {
-				newA := (a << 3) + mod
+				newA := shifted + mod
 				mc := m.Clone()
 				mc.A = newA
-				mc.Run()
-				if mc.Output[0] == target {
+				if err := mc.Run(ctx); err != nil {
+					return 0, err
+				}
+				if len(mc.Output) > 0 && mc.Output[0] == target {
 					newAlist = append(newAlist, newA)
 				}
 			}
 		}
 		alist = newAlist
 	}
+	if len(alist) == 0 && overflowed {
+		return 0, fmt.Errorf("%w: searching a quine of %d values", aoc.ErrOverflow, len(m.Program))
+	}
 
 	// now we have alist of possible A values
 	smallest := math.MaxInt
@@ -257,7 +289,138 @@ func (m *Machine) QuineSearch() int {
 			smallest = a
 		}
 	}
-	return smallest
+	return smallest, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// BigMachine is a Machine whose registers are math/big, for quines of
+// programs too long for an int's 21 octal digits
+type BigMachine struct {
+	A, B, C *big.Int // registers
+	I       int      // instruction pointer
+	Program []int
+	Output  []int
+}
+
+// Big returns a BigMachine with m's registers and program
+func (m *Machine) Big() *BigMachine {
+	return &BigMachine{
+		A:       big.NewInt(int64(m.A)),
+		B:       big.NewInt(int64(m.B)),
+		C:       big.NewInt(int64(m.C)),
+		I:       m.I,
+		Program: m.Program,
+		Output:  slices.Clone(m.Output),
+	}
+}
+
+// Step is Machine.Step; returns false if halted
+func (m *BigMachine) Step() bool {
+	if m.I+1 >= len(m.Program) {
+		return false
+	}
+	opCode, literal := m.Program[m.I], m.Program[m.I+1]
+
+	var combo *big.Int
+	switch literal {
+	case 0, 1, 2, 3:
+		combo = big.NewInt(int64(literal))
+	case 4:
+		combo = m.A
+	case 5:
+		combo = m.B
+	case 6:
+		combo = m.C
+	case 7:
+		// no combo
+	default:
+		return false // invalid operation
+	}
+	// A >> combo, where a combo too large to shift by leaves nothing
+	shiftA := func() *big.Int {
+		if !combo.IsUint64() || combo.Uint64() > uint64(m.A.BitLen()) {
+			return new(big.Int)
+		}
+		return new(big.Int).Rsh(m.A, uint(combo.Uint64()))
+	}
+
+	incrI := 2
+	switch opCode {
+	case 0: // adv
+		m.A = shiftA()
+	case 1: // bxl
+		m.B = new(big.Int).Xor(m.B, big.NewInt(int64(literal)))
+	case 2: // bst
+		m.B = new(big.Int).And(combo, big.NewInt(7))
+	case 3: // jnz
+		if m.A.Sign() != 0 {
+			m.I = literal
+			incrI = 0
+		}
+	case 4: // bxc
+		m.B = new(big.Int).Xor(m.B, m.C)
+	case 5: // out
+		m.Output = append(m.Output, int(new(big.Int).And(combo, big.NewInt(7)).Int64()))
+	case 6: // bdv
+		m.B = shiftA()
+	case 7: // cdv
+		m.C = shiftA()
+	default:
+		return false // invalid opcde
+	}
+
+	m.I += incrI
+	return true
+}
+
+// Run steps the machine until it halts, or ctx is done
+func (m *BigMachine) Run(ctx context.Context) error {
+	for steps := 1; m.Step(); steps++ {
+		if steps%4096 == 0 && ctx.Err() != nil {
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// QuineSearchBig is QuineSearch computed with math/big, so never overflows
+func (m *Machine) QuineSearchBig(ctx context.Context) (*big.Int, error) {
+	var alist []*big.Int
+	for a := range int64(8) {
+		alist = append(alist, big.NewInt(a))
+	}
+	for i := len(m.Program) - 1; i >= 0; i-- {
+		target := m.Program[i]
+		var newAlist []*big.Int
+		for _, a := range alist {
+			for mod := int64(0); mod < 8; mod++ {
+				newA := new(big.Int).Lsh(a, 3)
+				newA.Add(newA, big.NewInt(mod))
+				mc := m.Big()
+				mc.A = newA
+				if err := mc.Run(ctx); err != nil {
+					return nil, err
+				}
+				if len(mc.Output) > 0 && mc.Output[0] == target {
+					newAlist = append(newAlist, newA)
+				}
+			}
+		}
+		alist = newAlist
+	}
+
+	// now we have alist of possible A values
+	var smallest *big.Int
+	for _, a := range alist {
+		if smallest == nil || a.Cmp(smallest) < 0 {
+			smallest = a
+		}
+	}
+	if smallest == nil {
+		return big.NewInt(math.MaxInt), nil // as QuineSearch
+	}
+	return smallest, nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -378,38 +541,52 @@ func (tm TModel) View() string {
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func main() {
-	// Open and read data file
-	machineData, err := os.ReadFile(os.Args[1])
+func init() {
+	aoc.Register(aoc.Day{Day: 17, New: func() aoc.Solver { return &solver{} }, Interactive: interactive, Big: true})
+}
+
+type solver struct {
+	machine *Machine
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %s\n", err.Error())
-		os.Exit(1)
+		return err
 	}
+	s.machine, err = NewMachine(input)
+	return err
+}
 
-	// part 1
-	machine := NewMachine(string(machineData))
-	if machine == nil {
-		fmt.Fprintf(os.Stderr, "Bad machine data\n")
-		os.Exit(1)
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	machine := s.machine.Clone()
+	if err := machine.Run(ctx); err != nil {
+		return nil, err
 	}
+	return WithCommas(machine.Output), nil
+}
 
-	// part 2
-	machine = NewMachine(string(machineData))
-	if len(os.Args) > 2 {
-		a, err := strconv.Atoi(os.Args[2])
-		if err == nil && a != 0 {
-			machine.A = a
-			machine.StartA = a
-		}
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	if aoc.Big(ctx) {
+		return s.machine.QuineSearchBig(ctx)
 	}
+	return s.machine.QuineSearch(ctx)
+}
 
-	aval := machine.QuineSearch()
-	fmt.Print("\n15.2: ", aval, "\n\n")
+// interactive steps through the machine seeded with the quine value
+func interactive(ctx context.Context, input string) error {
+	machine, err := NewMachine(input)
+	if err != nil {
+		return err
+	}
+	aval, err := machine.QuineSearch(ctx)
+	if err != nil {
+		return err
+	}
 
 	tm := NewTModel(machine)
 	tm.m.A = aval
-	if _, err := tea.NewProgram(tm).Run(); err != nil {
-		fmt.Println("Error running program:", err)
-		os.Exit(1)
-	}
+	tm.m.StartA = aval
+	_, err = tea.NewProgram(tm).Run()
+	return err
 }
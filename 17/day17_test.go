@@ -0,0 +1,146 @@
+package day17
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 17) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 17, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 17, 2) }
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		m       Machine
+		want    Machine
+		compare func(got, want *Machine) bool
+	}{
+		{ // If register C contains 9, the program 2,6 would set register B to 1
+			Machine{C: 9, Program: []int{2, 6}}, Machine{B: 1},
+			func(got, want *Machine) bool { return got.B == want.B },
+		},
+		{ // If register A contains 10, the program 5,0,5,1,5,4 would output 0,1,2
+			Machine{A: 10, Program: []int{5, 0, 5, 1, 5, 4}}, Machine{Output: []int{0, 1, 2}},
+			func(got, want *Machine) bool { return slices.Equal(got.Output, want.Output) },
+		},
+		{ // If register A contains 2024, the program 0,1,5,4,3,0 would output 4,2,5,6,7,7,7,7,3,1,0 and leave 0 in A
+			Machine{A: 2024, Program: []int{0, 1, 5, 4, 3, 0}}, Machine{A: 0, Output: []int{4, 2, 5, 6, 7, 7, 7, 7, 3, 1, 0}},
+			func(got, want *Machine) bool { return got.A == want.A && slices.Equal(got.Output, want.Output) },
+		},
+		{ // If register B contains 29, the program 1,7 would set register B to 26
+			Machine{B: 29, Program: []int{1, 7}}, Machine{B: 26},
+			func(got, want *Machine) bool { return got.B == want.B },
+		},
+		{ // If register B contains 2024 and register C contains 43690, the program 4,0 would set register B to 44354
+			Machine{B: 2024, C: 43690, Program: []int{4, 0}}, Machine{B: 44354},
+			func(got, want *Machine) bool { return got.B == want.B },
+		},
+	}
+	for i, tt := range tests {
+		m := tt.m
+		if err := m.Run(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		if !tt.compare(&m, &tt.want) {
+			t.Errorf("case %d: got %s", i, m.View())
+		}
+	}
+}
+
+func TestQuineSearch(t *testing.T) {
+	m := Machine{Program: []int{0, 3, 5, 4, 3, 0}}
+	if got, err := m.QuineSearch(context.Background()); err != nil || got != 117440 {
+		t.Errorf("QuineSearch = %d, %v, want 117440", got, err)
+	}
+}
+
+func TestQuineSearchOverflow(t *testing.T) {
+	// the example quine padded with bxl 0s to 22 values needs 66 bits of A
+	program := []int{0, 3, 5, 4}
+	for len(program) < 20 {
+		program = append(program, 1, 0)
+	}
+	program = append(program, 3, 0)
+	m := Machine{Program: program}
+	if _, err := m.QuineSearch(context.Background()); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("QuineSearch: got %v, want ErrOverflow", err)
+	}
+
+	// A's octal digits are the program, shifted up one
+	want := new(big.Int)
+	for i := len(program) - 1; i >= 0; i-- {
+		want.Lsh(want, 3).Add(want, big.NewInt(int64(program[i])))
+	}
+	want.Lsh(want, 3)
+	got, err := m.QuineSearchBig(context.Background())
+	if err != nil || got.Cmp(want) != 0 {
+		t.Errorf("QuineSearchBig = %v, %v, want %v", got, err, want)
+	}
+	mb := m.Big()
+	mb.A = got
+	if err := mb.Run(context.Background()); err != nil || !slices.Equal(mb.Output, program) {
+		t.Errorf("BigMachine with A=%v output %v, want %v", got, mb.Output, program)
+	}
+
+	// the example's quine is the same either way
+	m = Machine{Program: []int{0, 3, 5, 4, 3, 0}}
+	if got, err := m.QuineSearchBig(context.Background()); err != nil || got.Int64() != 117440 {
+		t.Errorf("QuineSearchBig = %v, %v, want 117440", got, err)
+	}
+}
+
+func TestQuineSearchNoOutput(t *testing.T) {
+	// bxl 0 outputs nothing, so can't be a quine
+	m := Machine{Program: []int{1, 0}}
+	if got, err := m.QuineSearch(context.Background()); err != nil || got != math.MaxInt {
+		t.Errorf("QuineSearch = %v, %v, want MaxInt", got, err)
+	}
+	if got, err := m.QuineSearchBig(context.Background()); err != nil || !got.IsInt64() || got.Int64() != math.MaxInt {
+		t.Errorf("QuineSearchBig = %v, %v, want MaxInt", got, err)
+	}
+}
+
+func TestShiftPast63(t *testing.T) {
+	// adv with C = 70 divides A by 2^70, which once divided by zero
+	m := Machine{A: 1 << 40, C: 70, Program: []int{0, 6}}
+	if err := m.Run(context.Background()); err != nil || m.A != 0 {
+		t.Errorf("A >> 70 = %d, %v, want 0", m.A, err)
+	}
+	mb := (&Machine{A: 1 << 40, C: 70, Program: []int{0, 6}}).Big()
+	if err := mb.Run(context.Background()); err != nil || mb.A.Sign() != 0 {
+		t.Errorf("big A >> 70 = %v, %v, want 0", mb.A, err)
+	}
+}
+
+func TestRunCancel(t *testing.T) {
+	// 3,0 jumps back to itself forever while A is non-zero
+	m := Machine{A: 1, Program: []int{3, 0}}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := m.Run(ctx); err != context.DeadlineExceeded {
+		t.Errorf("Run = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func FuzzNewMachine(f *testing.F) {
+	aoctest.Fuzz(f, 17, func(t *testing.T, part int, answer aoc.Answer) {
+		if part != 1 {
+			return
+		}
+		for i, c := range answer.(string) {
+			if (i%2 == 0 && (c < '0' || c > '7')) || (i%2 == 1 && c != ',') {
+				t.Errorf("output %q is not comma-separated octal digits", answer)
+				break
+			}
+		}
+	})
+}
@@ -0,0 +1,176 @@
+// https://adventofcode.com/2024/day/2
+// aoc2024 run 2 --input 2/2.txt
+
+package day2
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+func abs(x int64) int64 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+type Report []int64
+
+// isReportSafe returns true if the report is safe, false otherwise.
+func isReportSafe(r Report) bool {
+	return firstUnsafeLevel(r) < 0
+}
+
+// firstUnsafeLevel returns the index of the level ending the first unsafe
+// step of the report, or -1 if it is safe
+func firstUnsafeLevel(r Report) int {
+	var hasBeenIncreasing bool
+	const minDiff, maxDiff = 1, 3
+	for i := 1; i < len(r); i++ { // note starting at 1
+		diff := r[i] - r[i-1]
+
+		// check adjacent level threshold
+		absDiff := abs(diff)
+		if absDiff < minDiff || absDiff > maxDiff {
+			return i
+		}
+
+		// we are not safe if we don't have same trend
+		isIncreasingNow := (diff > 0)
+		if i == 1 {
+			// set initial condition
+			hasBeenIncreasing = isIncreasingNow
+		}
+		if isIncreasingNow != hasBeenIncreasing {
+			return i
+		}
+	}
+	return -1
+}
+
+func dampenReport(r Report, pos int) Report {
+	// remove element at pos
+	// also return append(r[:pos], r[pos+1:]...)
+	// but that looks weird in debugging
+	var d Report
+	for i, v := range r {
+		if i != pos {
+			d = append(d, v)
+		}
+	}
+	return d
+}
+
+func isReportSafeDampened(r Report) bool {
+	// if it is safe, then report so
+	if isReportSafe(r) {
+		return true
+	}
+
+	// we are allowed to be safe with a level removed
+	// try every position, and we're safe if its safe
+	for i := 0; i < len(r); i++ {
+		dampenedReport := dampenReport(r, i)
+		if isReportSafe(dampenedReport) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isReportSafeDampenedFast is isReportSafeDampened without trying every
+// removal: only the levels either side of the first unsafe step, or the
+// first level, which sets the trend, can make the report safe.
+// Only --verify uses it, checking it against isReportSafeDampened.
+func isReportSafeDampenedFast(r Report) bool {
+	bad := firstUnsafeLevel(r)
+	if bad < 0 {
+		return true
+	}
+	for _, pos := range []int{bad - 1, bad, 0} {
+		if isReportSafe(dampenReport(r, pos)) {
+			return true
+		}
+	}
+	return false
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 2, New: func() aoc.Solver { return &solver{} }, Generate: generate})
+}
+
+// parseReports reads one Report of levels per line
+func parseReports(input string) ([]Report, error) {
+	var reports []Report
+	for i, line := range parse.Lines(input) {
+		var report Report
+		strs := strings.Fields(line)
+		if len(strs) == 0 {
+			return nil, parse.Errorf(i+1, "empty report")
+		}
+		for _, str := range strs {
+			v, err := strconv.ParseInt(str, 10, 64)
+			if err != nil {
+				return nil, parse.Errorf(i+1, "bad level %q", str)
+			}
+			report = append(report, v)
+		}
+
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+type solver struct {
+	reports []Report
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.reports, err = parseReports(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	safeCount := 0
+	for _, report := range s.reports {
+		if isReportSafe(report) {
+			safeCount++
+		}
+	}
+	return safeCount, nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	safeCountDampened := 0
+	for _, report := range s.reports {
+		if isReportSafeDampened(report) {
+			safeCountDampened++
+		}
+	}
+	return safeCountDampened, nil
+}
+
+// Verify checks the fast dampened safety check against trying every removal
+func (s *solver) Verify(ctx context.Context) error {
+	for i, report := range s.reports {
+		fast, naive := isReportSafeDampenedFast(report), isReportSafeDampened(report)
+		if fast != naive {
+			return &aoc.Mismatch{What: fmt.Sprintf("report %d dampened safety", i+1), Fast: fast, Naive: naive}
+		}
+	}
+	return nil
+}
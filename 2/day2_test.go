@@ -0,0 +1,81 @@
+package day2
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 2) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 2, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 2, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 2, 2) }
+
+func TestReportSafety(t *testing.T) {
+	tests := []struct {
+		report         Report
+		safe, dampened bool
+	}{
+		{Report{7, 6, 4, 2, 1}, true, true},
+		{Report{1, 2, 7, 8, 9}, false, false},
+		{Report{9, 7, 6, 2, 1}, false, false},
+		{Report{1, 3, 2, 4, 5}, false, true},
+		{Report{8, 6, 4, 4, 1}, false, true},
+		{Report{1, 3, 6, 7, 9}, true, true},
+	}
+	for _, tt := range tests {
+		if got := isReportSafe(tt.report); got != tt.safe {
+			t.Errorf("isReportSafe(%v) = %v, want %v", tt.report, got, tt.safe)
+		}
+		if got := isReportSafeDampened(tt.report); got != tt.dampened {
+			t.Errorf("isReportSafeDampened(%v) = %v, want %v", tt.report, got, tt.dampened)
+		}
+	}
+}
+
+// TestDampenedFast checks the fast dampened check against trying every
+// removal, on every short report of small levels
+func TestDampenedFast(t *testing.T) {
+	report := make(Report, 5)
+	var fill func(i int)
+	fill = func(i int) {
+		if i == len(report) {
+			if fast, naive := isReportSafeDampenedFast(report), isReportSafeDampened(report); fast != naive {
+				t.Errorf("%v: fast %v, naive %v", report, fast, naive)
+			}
+			return
+		}
+		for level := int64(0); level < 7; level++ {
+			report[i] = level
+			fill(i + 1)
+		}
+	}
+	fill(0)
+}
+
+func FuzzParseReports(f *testing.F) {
+	aoctest.Fuzz(f, 2, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: safe count %v is negative", part, answer)
+		}
+	})
+}
+
+// dampening can only make more reports safe
+func FuzzDampening(f *testing.F) {
+	aoctest.Seed(f, 2)
+	f.Fuzz(func(t *testing.T, input string) {
+		reports, err := parseReports(input)
+		if err != nil {
+			return
+		}
+		for _, report := range reports {
+			if isReportSafe(report) && !isReportSafeDampened(report) {
+				t.Errorf("%v is safe, but not when dampened", report)
+			}
+		}
+	})
+}
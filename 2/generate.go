@@ -0,0 +1,37 @@
+package day2
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// generate makes size reports, mostly gently increasing or decreasing,
+// some with one bad level and some with several
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	var sb strings.Builder
+	for i := 0; i < size; i++ {
+		levels := make([]int, 5+rng.IntN(4))
+		dir := 1
+		if rng.IntN(2) == 0 {
+			dir = -1
+		}
+		levels[0] = 30 + rng.IntN(40)
+		for j := 1; j < len(levels); j++ {
+			levels[j] = levels[j-1] + dir*(1+rng.IntN(3))
+		}
+		for bad := rng.IntN(4) - 1; bad > 0; bad-- {
+			levels[rng.IntN(len(levels))] += rng.IntN(9) - 4
+		}
+
+		for j, level := range levels {
+			if j > 0 {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(strconv.Itoa(level))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -1,14 +1,18 @@
 // https://adventofcode.com/2024/day/3
-// go run 3/main.go 3/3.txt
+// aoc2024 run 3 --input 3/3.txt
 
-package main
+package day3
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io"
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
 )
 
 type MulOp struct {
@@ -105,31 +109,33 @@ func collectMulOpsDoDont(src string) []MulOp {
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func main() {
-	// Open and read data file
-	source, err := os.ReadFile(os.Args[1])
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %s\n", err.Error())
-		os.Exit(1)
-	}
+func init() {
+	aoc.Register(aoc.Day{Day: 3, New: func() aoc.Solver { return &solver{} }, Generate: generate})
+}
 
-	// part 1
+func sumMulOps(mulOps []MulOp) int {
 	sumResult := 0
-	mulOps := collectMulOps(string(source))
 	for _, mulOp := range mulOps {
-		if err == nil {
-			sumResult += (mulOp.A * mulOp.B)
-		}
+		sumResult += (mulOp.A * mulOp.B)
 	}
-	fmt.Println("3.1:", sumResult)
+	return sumResult
+}
 
-	// part 2
-	sumResult = 0
-	mulOpsDoDont := collectMulOpsDoDont(string(source))
-	for _, mulOp := range mulOpsDoDont {
-		if err == nil {
-			sumResult += (mulOp.A * mulOp.B)
-		}
-	}
-	fmt.Println("3.2:", sumResult)
+// solver scans the corrupted memory as-is, there is nothing to parse ahead
+type solver struct {
+	memory string
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	var err error
+	s.memory, err = parse.Read(r)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	return sumMulOps(collectMulOps(s.memory)), nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	return sumMulOps(collectMulOpsDoDont(s.memory)), nil
 }
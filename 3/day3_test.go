@@ -0,0 +1,38 @@
+package day3
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 3) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 3, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 3, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 3, 2) }
+
+func TestCollectMulOps(t *testing.T) {
+	ops := collectMulOps("mul(44,46)mul(4*mul(123,4)mul ( 2 , 4 )")
+	want := []MulOp{{44, 46}, {123, 4}}
+	if len(ops) != len(want) {
+		t.Fatalf("got %v, want %v", ops, want)
+	}
+	for i := range want {
+		if ops[i] != want[i] {
+			t.Errorf("op %d: got %v, want %v", i, ops[i], want[i])
+		}
+	}
+}
+
+func TestCollectMulOpsDoDont(t *testing.T) {
+	ops := collectMulOpsDoDont("mul(1,2)don't()mul(3,4)do()mul(5,6)")
+	if got := sumMulOps(ops); got != 1*2+5*6 {
+		t.Errorf("got %d, want %d", got, 1*2+5*6)
+	}
+}
+
+func FuzzCollectMulOps(f *testing.F) {
+	aoctest.Fuzz(f, 3, nil)
+}
@@ -0,0 +1,44 @@
+package day3
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// near-miss instructions that must not count
+var corruptions = []string{
+	"mul(4*", "mul ( 2 , 4 )", "mul[3,7]", "mul(6,9!", "?(12,34)", "mul(1234,5)",
+	"do_not_mul(5,5)", "don't", "do(", "from()", "what()", "select()", "mul(,3)",
+}
+
+const junk = "!@#$%^&*()[]{}<>?/,;:'+-_ xmulwhydon't"
+
+// generate makes size fragments of corrupted memory: real mul, do and don't
+// instructions among near misses and junk, wrapped into lines
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	var sb strings.Builder
+	for i := 0; i < size; i++ {
+		switch rng.IntN(6) {
+		case 0, 1:
+			fmt.Fprintf(&sb, "mul(%d,%d)", rng.IntN(1000), rng.IntN(1000))
+		case 2:
+			if rng.IntN(2) == 0 {
+				sb.WriteString("do()")
+			} else {
+				sb.WriteString("don't()")
+			}
+		case 3:
+			sb.WriteString(corruptions[rng.IntN(len(corruptions))])
+		default:
+			for n := rng.IntN(8); n >= 0; n-- {
+				sb.WriteByte(junk[rng.IntN(len(junk))])
+			}
+		}
+		if i%20 == 19 {
+			sb.WriteByte('\n')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,140 @@
+// https://adventofcode.com/2024/day/4
+// aoc2024 run 4 --input 4/4.txt
+
+package day4
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"io"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Board struct {
+	puzzle string
+	grid   *grid.Grid[byte]
+}
+
+func NewBoard(puzzle string) (*Board, error) {
+	g, err := grid.Parse(puzzle)
+	if err != nil {
+		return nil, err
+	}
+	return &Board{
+		puzzle: puzzle,
+		grid:   g,
+	}, nil
+}
+
+// CharAt returns the character at the given position.
+// Returns 0 if out-of-bounds
+func (b *Board) CharAt(pt grid.Point) byte {
+	return b.grid.GetOr(pt, 0)
+}
+
+// StringLine returns a string of characters from the board of max `length`
+// starting at pt and stepping in unit direction dir.
+func (b *Board) StringLine(pt grid.Point, length int, dir grid.Point) string {
+	// build the string via iteration
+	var buffer bytes.Buffer
+	for i := 0; i < length; i++ {
+		if c := b.CharAt(pt); c == 0 {
+			// out of bounds, stop
+			break
+		} else {
+			buffer.WriteByte(c)
+		}
+		pt = pt.Add(dir)
+	}
+	return buffer.String()
+}
+
+func (b *Board) CountAt(word string, pt grid.Point) int {
+	if word == "" || b.CharAt(pt) != word[0] {
+		return 0 // quick exit
+	}
+
+	// try every direction: orthogonal and diagonal
+	count := 0
+	for _, dir := range grid.Dirs8 {
+		if b.StringLine(pt, len(word), dir) == word {
+			count += 1
+		}
+	}
+	return count
+}
+
+func (b *Board) CountWord(word string) int {
+	// we are going to find Xs and search from there.
+	sum := 0
+	for pt := range b.grid.Points() {
+		sum += b.CountAt(word, pt)
+	}
+	return sum
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func testMS(a, b byte) bool {
+	if (a == 'M' && b == 'S') || (a == 'S' && b == 'M') {
+		return true
+	}
+	return false
+}
+func (b *Board) CountX_MAS() int {
+	// we are going to find "MAS" shaped line an X
+	count := 0
+	for pt, c := range b.grid.All() {
+		// is it an "A"
+		if c != 'A' {
+			continue
+		}
+		// great it's an A, let's sample the corners
+		ul := b.CharAt(pt.Add(grid.UpLeft))
+		lr := b.CharAt(pt.Add(grid.DownRight))
+		ll := b.CharAt(pt.Add(grid.DownLeft))
+		ur := b.CharAt(pt.Add(grid.UpRight))
+		if testMS(ul, lr) && testMS(ll, ur) {
+			count += 1
+		}
+	}
+	return count
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 4, New: func() aoc.Solver { return &solver{word: "XMAS"} }, Generate: generate})
+}
+
+type solver struct {
+	board *Board
+	word  string // to find in part 1
+}
+
+func (s *solver) DefineParams(fs *flag.FlagSet) {
+	fs.StringVar(&s.word, "word", s.word, "word to find in part 1")
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.board, err = NewBoard(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	return s.board.CountWord(s.word), nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	return s.board.CountX_MAS(), nil
+}
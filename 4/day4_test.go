@@ -0,0 +1,50 @@
+package day4
+
+import (
+	"os"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 4) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 4, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 4, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 4, 2) }
+
+func TestCountWord(t *testing.T) {
+	puzzle, err := os.ReadFile("4.test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	board, err := NewBoard(string(puzzle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := board.CountWord("XMAS"); got != 18 {
+		t.Errorf("CountWord(XMAS) = %d, want 18", got)
+	}
+	if got := board.CountWord("SAMX"); got != 18 {
+		t.Errorf("CountWord(SAMX) = %d, want 18", got)
+	}
+	if got := board.CountX_MAS(); got != 9 {
+		t.Errorf("CountX_MAS = %d, want 9", got)
+	}
+}
+
+func TestNewBoardRagged(t *testing.T) {
+	if _, err := NewBoard("XMAS\nXM\n"); err == nil {
+		t.Error("expected error for ragged board")
+	}
+}
+
+func FuzzNewBoard(f *testing.F) {
+	aoctest.Fuzz(f, 4, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: count %v is negative", part, answer)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package day4
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// generate makes a size x size word search of the letters in XMAS
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	g := grid.New[byte](size, size)
+	for pt := range g.Points() {
+		g.Set(pt, "XMAS"[rng.IntN(4)])
+	}
+	return grid.Text(g)
+}
@@ -1,13 +1,16 @@
 // https://adventofcode.com/2024/day/5
-// go run 5/main.go 5/5.txt
+// aoc2024 run 5 --input 5/5.txt
 
-package main
+package day5
 
 import (
-	"fmt"
-	"os"
-	"strconv"
+	"context"
+	"io"
+	"slices"
 	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
 )
 
 type PageOrdering struct {
@@ -25,29 +28,49 @@ type Rules struct {
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func NewRules(rulesStr string) *Rules {
+// NewRules parses the page ordering rules, a blank line, then the updates
+func NewRules(rulesStr string) (*Rules, error) {
 	rules := &Rules{}
 
-	separator := strings.Index(rulesStr, "\n\n")
-	for _, line := range strings.Split(rulesStr[:separator], "\n") {
+	lines := parse.Lines(rulesStr)
+	separator := slices.Index(lines, "")
+	if separator == -1 {
+		return nil, parse.Errorf(len(lines), "expected a blank line between orderings and updates")
+	}
+
+	for i, line := range lines[:separator] {
 		fields := strings.Split(line, "|")
-		if len(fields) == 2 {
-			before, _ := strconv.Atoi(fields[0])
-			after, _ := strconv.Atoi(fields[1])
-			rules.Orderings = append(rules.Orderings, PageOrdering{Before: Page(before), After: Page(after)})
+		if len(fields) != 2 {
+			return nil, parse.Errorf(i+1, "expected '<before>|<after>', got %q", line)
+		}
+		before, err := parse.Int(i+1, fields[0])
+		if err != nil {
+			return nil, err
 		}
+		after, err := parse.Int(i+1, fields[1])
+		if err != nil {
+			return nil, err
+		}
+		rules.Orderings = append(rules.Orderings, PageOrdering{Before: Page(before), After: Page(after)})
 	}
 
-	for _, line := range strings.Split(rulesStr[separator+2:], "\n") {
+	for i, line := range lines[separator+1:] {
+		lineNum := separator + i + 2
 		var update Update
 		pages := strings.Split(line, ",")
 		for _, page := range pages {
-			pageNum, _ := strconv.Atoi(page)
+			pageNum, err := parse.Int(lineNum, page)
+			if err != nil {
+				return nil, err
+			}
 			update = append(update, Page(pageNum))
 		}
 		rules.Updates = append(rules.Updates, update)
 	}
-	return rules
+	if len(rules.Updates) == 0 {
+		return nil, parse.Errorf(len(lines), "expected updates after the blank line")
+	}
+	return rules, nil
 }
 
 ///////////////////////////////////////////////////////////////////////////////
@@ -112,15 +135,14 @@ func (r *Rules) repairUpdate(update Update) Update {
 	return update
 }
 
-func (r *Rules) findAndRepairUpdates() []Update {
+func (r *Rules) findAndRepairUpdates(ctx context.Context) []Update {
 	var repaired []Update
 
 	for _, update := range r.Updates {
 		if !r.isUpdateCorrect(update) {
-			fmt.Printf("%v\n", update)
-			rp := r.repairUpdate(update)
+			rp := r.repairUpdate(slices.Clone(update))
 			repaired = append(repaired, rp)
-			fmt.Printf("%v\n", rp)
+			aoc.Logger(ctx).Debug("repaired update", "update", update, "repaired", rp)
 		}
 	}
 	return repaired
@@ -140,27 +162,29 @@ func sumUpdateMiddlePages(updates []Update) int {
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func main() {
-	// Open and read data file
-	rulesData, err := os.ReadFile(os.Args[1])
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %s\n", err.Error())
-		os.Exit(1)
-	}
+func init() {
+	aoc.Register(aoc.Day{Day: 5, New: func() aoc.Solver { return &solver{} }, Generate: generate})
+}
+
+type solver struct {
+	rules *Rules
+}
 
-	rules := NewRules(string(rulesData))
-	if rules == nil {
-		fmt.Fprintf(os.Stderr, "Error creating Rules\n")
-		os.Exit(1)
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
 	}
+	s.rules, err = NewRules(input)
+	return err
+}
 
-	// part 1
-	correctUpdates := rules.findCorrectUpdates()
-	sumMiddles := sumUpdateMiddlePages(correctUpdates)
-	fmt.Println("5.1:", sumMiddles)
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	correctUpdates := s.rules.findCorrectUpdates()
+	return sumUpdateMiddlePages(correctUpdates), nil
+}
 
-	// part 2
-	repairedUpdates := rules.findAndRepairUpdates()
-	sumMiddles = sumUpdateMiddlePages(repairedUpdates)
-	fmt.Println("5.2:", sumMiddles)
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	repairedUpdates := s.rules.findAndRepairUpdates(ctx)
+	return sumUpdateMiddlePages(repairedUpdates), nil
 }
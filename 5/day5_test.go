@@ -0,0 +1,44 @@
+package day5
+
+import (
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 5) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 5, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 5, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 5, 2) }
+
+func TestRepairUpdate(t *testing.T) {
+	data, err := os.ReadFile("5.test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := NewRules(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct{ update, want Update }{
+		{Update{75, 97, 47, 61, 53}, Update{97, 75, 47, 61, 53}},
+		{Update{61, 13, 29}, Update{61, 29, 13}},
+		{Update{97, 13, 75, 29, 47}, Update{97, 75, 47, 29, 13}},
+	}
+	for _, tt := range tests {
+		if rules.isUpdateCorrect(tt.update) {
+			t.Errorf("%v should be incorrect", tt.update)
+		}
+		if got := rules.repairUpdate(slices.Clone(tt.update)); !slices.Equal(got, tt.want) {
+			t.Errorf("repairUpdate(%v) = %v, want %v", tt.update, got, tt.want)
+		}
+	}
+}
+
+func FuzzNewRules(f *testing.F) {
+	aoctest.Fuzz(f, 5, nil)
+}
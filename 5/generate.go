@@ -0,0 +1,40 @@
+package day5
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+// generate makes page ordering rules from a random total order of pages, so
+// they are acyclic, then size updates of an odd number of those pages
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	numPages := min(5+size/2, 49)
+	pages := rng.Perm(90)[:numPages] // in order, as page-10
+	var sb strings.Builder
+
+	var orderings []string
+	for i := range pages {
+		for j := i + 1; j < len(pages); j++ {
+			orderings = append(orderings, fmt.Sprintf("%d|%d", pages[i]+10, pages[j]+10))
+		}
+	}
+	rng.Shuffle(len(orderings), func(i, j int) { orderings[i], orderings[j] = orderings[j], orderings[i] })
+	for _, o := range orderings {
+		sb.WriteString(o)
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+
+	for i := 0; i < size; i++ {
+		n := 3 + 2*rng.IntN(min(numPages-1, 20)/2)
+		update := make([]string, n)
+		for j, k := range rng.Perm(numPages)[:n] {
+			update[j] = fmt.Sprint(pages[k] + 10)
+		}
+		sb.WriteString(strings.Join(update, ","))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
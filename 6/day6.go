@@ -0,0 +1,373 @@
+// https://adventofcode.com/2024/day/6
+// aoc2024 run 6 --input 6/6.txt
+
+package day6
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/graph"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Maze struct {
+	Floorplan *grid.Grid[byte]
+	Coloring  *grid.Grid[Color]
+	GuardPos  grid.Point
+
+	OnStep func() bool // if set, called after each step of a walk, e.g. to record it; false stops the walk
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+const (
+	GuardUp     = byte('^')
+	GuardDown   = byte('v')
+	GuardLeft   = byte('<')
+	GuardRight  = byte('>')
+	Obstacle    = byte('#')
+	Obstruction = byte('O')
+	Emptiness   = byte('.') // Buddha says that the emptiness of the maze is what gives it its function.
+	Blank       = byte(' ')
+	HMove       = byte('-')
+	VMove       = byte('|')
+	HVMove      = byte('+')
+)
+
+var guardRunes = []byte{GuardUp, GuardDown, GuardLeft, GuardRight}
+
+type Color byte
+
+const (
+	ColorNone  = 0
+	ColorUp    = 1
+	ColorDown  = 2
+	ColorLeft  = 4
+	ColorRight = 8
+	ColorHoriz = ColorLeft | ColorRight
+	ColorVert  = ColorUp | ColorDown
+	ColorAll   = ColorUp | ColorDown | ColorLeft | ColorRight
+)
+
+func ColorFromGuard(g byte) Color {
+	switch g {
+	case GuardUp:
+		return ColorUp
+	case GuardDown:
+		return ColorDown
+	case GuardLeft:
+		return ColorLeft
+	case GuardRight:
+		return ColorRight
+	default:
+		return ColorNone
+	}
+}
+
+func (c Color) AsColorGlyph() byte {
+	h := (c & ColorHoriz) != 0
+	v := (c & ColorVert) != 0
+	if h && !v {
+		return HMove
+	} else if !h && v {
+		return VMove
+	} else if h && v {
+		return HVMove
+	} else {
+		return Blank
+	}
+}
+
+func isGuard(c byte) bool {
+	if c == GuardUp || c == GuardDown || c == GuardLeft || c == GuardRight {
+		return true
+	}
+	return false
+}
+
+func isObstacle(c byte) bool {
+	return c == Obstacle || c == Obstruction
+}
+
+// rotates the guard right (clockwise) 90 degrees
+func RotateGuard(g byte) byte {
+	switch g {
+	case GuardUp:
+		return GuardRight
+	case GuardRight:
+		return GuardDown
+	case GuardDown:
+		return GuardLeft
+	case GuardLeft:
+		return GuardUp
+	default: // pass through bad char?
+		return g
+	}
+}
+
+// GuardDir returns the unit direction the guard faces
+func GuardDir(g byte) grid.Point {
+	switch g {
+	case GuardUp:
+		return grid.Up
+	case GuardDown:
+		return grid.Down
+	case GuardLeft:
+		return grid.Left
+	case GuardRight:
+		return grid.Right
+	default:
+		return grid.Point{}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (m *Maze) IsInBounds(p grid.Point) bool {
+	return m.Floorplan.InBounds(p)
+}
+
+func (m *Maze) IsObstacle(p grid.Point) bool {
+	return isObstacle(m.Floorplan.GetOr(p, Emptiness)) // out of bounds is not an obstacle
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (m *Maze) ClearColoring() {
+	m.Coloring = grid.NewLike[Color](m.Floorplan)
+}
+
+func NewMaze(mazeStr string) (*Maze, error) {
+	floorplan, err := grid.ParseOnly(mazeStr, string(append([]byte{Emptiness, Obstacle, Obstruction}, guardRunes...)))
+	if err != nil {
+		return nil, err
+	}
+
+	// find the one guard on floorplan
+	guards := floorplan.FindAll(isGuard)
+	if len(guards) == 0 {
+		return nil, errors.New("no guard in maze")
+	} else if len(guards) > 1 {
+		return nil, parse.ErrorfAt(guards[1].Y+1, guards[1].X+1, "more than one guard in maze")
+	}
+
+	maze := &Maze{
+		Floorplan: floorplan,
+		GuardPos:  guards[0],
+	}
+	maze.ClearColoring()
+	return maze, nil
+}
+
+func (m *Maze) Clone() *Maze {
+	newMaze := &Maze{
+		Floorplan: m.Floorplan.Clone(),
+		GuardPos:  m.GuardPos,
+	}
+	newMaze.ClearColoring()
+	return newMaze
+}
+
+func (m *Maze) GetColorCount() int {
+	return m.Coloring.Count(func(c Color) bool { return c != ColorNone })
+}
+
+// Returns the color at a point
+func (m *Maze) GetColor(pt grid.Point) Color {
+	return m.Coloring.At(pt)
+}
+
+// Sets the color at a point
+func (m *Maze) SetColor(pt grid.Point, color Color) {
+	m.Coloring.Set(pt, color)
+}
+
+// Blends the colors the maze at a point, returning the new color
+func (m *Maze) BlendColor(pt grid.Point, mixColor Color) Color {
+	newColor := m.GetColor(pt) | mixColor
+	m.SetColor(pt, newColor)
+	return newColor
+}
+
+// Get floor tile at a point
+func (m *Maze) GetFloor(pt grid.Point) byte {
+	return m.Floorplan.At(pt)
+}
+
+// Sets the floor tile at a point
+func (m *Maze) SetFloor(pt grid.Point, tile byte) {
+	m.Floorplan.Set(pt, tile)
+}
+
+// ColoringView renders the guard's path
+func (m *Maze) ColoringView() string {
+	return m.Coloring.Render(Color.AsColorGlyph)
+}
+
+// PathGrid draws the floorplan with the guard's path over its empty floor
+func (m *Maze) PathGrid() *grid.Grid[byte] {
+	g := m.Floorplan.Clone()
+	for pt, tile := range m.Floorplan.All() {
+		if tile == Emptiness && m.GetColor(pt) != ColorNone {
+			g.Set(pt, m.GetColor(pt).AsColorGlyph())
+		}
+	}
+	return g
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// guardState is where the guard stands and which way it faces
+type guardState struct {
+	pos    grid.Point
+	facing byte
+}
+
+// guardSteps lists the guard's one next state: a step forward, or a turn
+// right at an obstacle, or none once it would leave the maze.  The walk is
+// the path through these states, and a loop is a state seen twice.
+func (m *Maze) guardSteps(g guardState) iter.Seq[guardState] {
+	return func(yield func(guardState) bool) {
+		ahead := g.pos.Add(GuardDir(g.facing))
+		switch {
+		case !m.IsInBounds(ahead):
+		case m.IsObstacle(ahead):
+			yield(guardState{g.pos, RotateGuard(g.facing)})
+		default:
+			yield(guardState{ahead, g.facing})
+		}
+	}
+}
+
+// walked marks the guard's states on the Coloring, as a graph.Set
+type walked struct{ m *Maze }
+
+func (w walked) Add(g guardState) bool {
+	color := ColorFromGuard(g.facing)
+	if w.m.GetColor(g.pos)&color != 0 {
+		return false // been here before, facing this way
+	}
+	w.m.BlendColor(g.pos, color)
+	return true
+}
+
+// iterates the guard walking through the maze, coloring the map
+// Returns false if there is a loop or OnStep stopped it, true if the guard exits
+func (m *Maze) WalkGuardAndColor() bool {
+	guardChar := m.GetFloor(m.GuardPos)
+	if !isGuard(guardChar) {
+		panic("bad guard position")
+	}
+	m.ClearColoring()
+	last := guardState{m.GuardPos, guardChar}
+	for g := range graph.DFSWith(m.guardSteps, walked{m}, last) {
+		if g != last {
+			// Guard turns or advances...
+			// Empty current spot, and move guard
+			m.SetFloor(m.GuardPos, Emptiness)
+			m.SetFloor(g.pos, g.facing)
+			m.GuardPos = g.pos
+		}
+		if g != last && m.OnStep != nil && !m.OnStep() {
+			return false
+		}
+		last = g
+	}
+	// the walk ended where the guard either left or would repeat itself
+	for range m.guardSteps(last) {
+		return false
+	}
+	return true
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (m *Maze) SearchObstructionPositions(ctx context.Context) (int, error) {
+	// an obstruction off the guard's path can't change its walk, so only
+	// count the obstruction positions on it
+	path := m.Clone()
+	path.WalkGuardAndColor()
+	infCount := 0
+	for pt, color := range path.Coloring.All() {
+		if pt == m.GuardPos || color == ColorNone {
+			continue // we don't put one where the guard starts
+		}
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		// place obstruction at pt and see if guard can walk through
+		newMaze := m.Clone()
+		newMaze.SetFloor(pt, Obstruction)
+		if !newMaze.WalkGuardAndColor() {
+			infCount++
+		}
+	}
+	return infCount, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 6, New: func() aoc.Solver { return &solver{} }, Generate: generate, Replay: replayWalk})
+}
+
+type solver struct {
+	maze *Maze
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.maze, err = NewMaze(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	maze := s.maze.Clone()
+	maze.WalkGuardAndColor()
+	aoc.Logger(ctx).Debug("guard walked", "floorplan", aoc.Lazy(func() string { return grid.Text(maze.Floorplan) }),
+		"path", aoc.Lazy(maze.ColoringView))
+	aoc.Snapshot(ctx, "path", maze.PathGrid)
+	return maze.GetColorCount(), nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	return s.maze.SearchObstructionPositions(ctx)
+}
+
+// replayWalk records the guard's walk, step by step
+func replayWalk(ctx context.Context, input string, rec *replay.Recorder) error {
+	maze, err := NewMaze(input)
+	if err != nil {
+		return err
+	}
+	maze.ClearColoring()
+	rec.Record(maze.PathGrid(), "start")
+	steps := 0
+	maze.OnStep = func() bool {
+		steps++
+		rec.Record(maze.PathGrid(), fmt.Sprintf("step %d", steps))
+		return ctx.Err() == nil
+	}
+	left := maze.WalkGuardAndColor()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	outcome := "guard is stuck in a loop"
+	if left {
+		outcome = fmt.Sprintf("guard left after visiting %d positions", maze.GetColorCount())
+	}
+	rec.Done(maze.PathGrid(), outcome)
+	return nil
+}
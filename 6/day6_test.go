@@ -0,0 +1,64 @@
+package day6
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 6) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 6, 20) }
+
+func TestReplay(t *testing.T) {
+	frames := aoctest.Replay(t, 6)
+	if got := frames[len(frames)-1].Caption; got != "guard left after visiting 41 positions" {
+		t.Errorf("final caption %q", got)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 6, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 6, 2) }
+
+func TestSearchObstructionPositions(t *testing.T) {
+	data, err := os.ReadFile("6.test.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	maze, err := NewMaze(string(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !maze.Clone().WalkGuardAndColor() {
+		t.Error("guard should exit the example maze")
+	}
+	if got, err := maze.SearchObstructionPositions(context.Background()); err != nil || got != 6 {
+		t.Errorf("SearchObstructionPositions = %d, %v, want 6", got, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := maze.SearchObstructionPositions(ctx); err != context.Canceled {
+		t.Errorf("cancelled SearchObstructionPositions: got %v, want context.Canceled", err)
+	}
+}
+
+func TestNewMazeNoGuard(t *testing.T) {
+	if _, err := NewMaze("..#\n...\n"); err == nil {
+		t.Error("expected error for maze without a guard")
+	}
+}
+
+func FuzzNewMaze(f *testing.F) {
+	aoctest.Fuzz(f, 6, func(t *testing.T, part int, answer aoc.Answer) {
+		if part == 1 && answer.(int) < 1 {
+			t.Errorf("guard visited %v positions, not even the start", answer)
+		}
+		if part == 2 && answer.(int) < 0 {
+			t.Errorf("%v obstruction positions is negative", answer)
+		}
+	})
+}
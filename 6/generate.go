@@ -0,0 +1,22 @@
+package day6
+
+import (
+	"context"
+	"math/rand/v2"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// generate makes a size x size lab with scattered obstacles and one guard
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	g := grid.New[byte](size, size)
+	for pt := range g.Points() {
+		if rng.IntN(10) == 0 {
+			g.Set(pt, Obstacle)
+		} else {
+			g.Set(pt, Emptiness)
+		}
+	}
+	g.Set(grid.Point{X: rng.IntN(size), Y: rng.IntN(size)}, guardRunes[rng.IntN(len(guardRunes))])
+	return grid.Text(g)
+}
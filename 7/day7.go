@@ -0,0 +1,326 @@
+// https://adventofcode.com/2024/day/7
+// aoc2024 run 7 --input 7/7.txt
+
+package day7
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/checked"
+	"github.com/neomantra/aoc2024/memo"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+// Operators are always evaluated left-to-right, not according to precedence rules.
+
+type Equation struct {
+	Result int
+	Args   []int
+}
+
+// Calc returns the result of applying ops to the args, wrapping
+// aoc.ErrOverflow if it outgrows an int
+func (e Equation) Calc(ops []Op) (int, error) {
+	if len(ops) != len(e.Args)-1 {
+		return 0, fmt.Errorf("Wrong number of operators")
+	}
+	result := e.Args[0] // seed with first arg
+	for i := 0; i < len(e.Args)-1; i++ {
+		next, ok := ops[i].Apply(result, e.Args[i+1])
+		if !ok {
+			return 0, fmt.Errorf("%w: %d %s %d", aoc.ErrOverflow, result, ops[i].Glyph(), e.Args[i+1])
+		}
+		result = next
+	}
+	return result, nil
+}
+
+// CalcBig is Calc computed with math/big, so never overflows
+func (e Equation) CalcBig(ops []Op) (*big.Int, error) {
+	if len(ops) != len(e.Args)-1 {
+		return nil, fmt.Errorf("Wrong number of operators")
+	}
+	result := big.NewInt(int64(e.Args[0])) // seed with first arg
+	for i := 0; i < len(e.Args)-1; i++ {
+		result = ops[i].ApplyBig(result, result, big.NewInt(int64(e.Args[i+1])))
+	}
+	return result, nil
+}
+
+// growing reports whether every arg is positive, so no operator makes a
+// result smaller: once past an int, it can't come back to the result
+func (e Equation) growing() bool {
+	for _, arg := range e.Args {
+		if arg < 1 {
+			return false
+		}
+	}
+	return true
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Op interface {
+	Glyph() string
+	// Apply returns a op b, and false if it overflows an int
+	Apply(a, b int) (int, bool)
+	// ApplyBig sets z to a op b, returning z
+	ApplyBig(z, a, b *big.Int) *big.Int
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+type AddOp struct{}
+
+func (op AddOp) Glyph() string { return "+" }
+
+func (op AddOp) Apply(a, b int) (int, bool) { return checked.Add(a, b) }
+
+func (op AddOp) ApplyBig(z, a, b *big.Int) *big.Int { return z.Add(a, b) }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type MulOp struct{}
+
+func (op MulOp) Glyph() string { return "*" }
+
+func (op MulOp) Apply(a, b int) (int, bool) { return checked.Mul(a, b) }
+
+func (op MulOp) ApplyBig(z, a, b *big.Int) *big.Int { return z.Mul(a, b) }
+
+///////////////////////////////////////////////////////////////////////////////
+
+type ConcatOp struct{}
+
+func (op ConcatOp) Glyph() string { return "||" }
+
+func (op ConcatOp) Apply(a, b int) (int, bool) {
+	newA, newB := a, b
+	for newB > 0 {
+		var ok bool
+		if newA, ok = checked.Mul(newA, 10); !ok {
+			return 0, false
+		}
+		newB /= 10
+	}
+	return checked.Add(newA, b)
+}
+
+func (op ConcatOp) ApplyBig(z, a, b *big.Int) *big.Int {
+	digits := 0
+	if b.Sign() > 0 {
+		digits = len(b.String())
+	}
+	shift := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	shift.Mul(a, shift)
+	return z.Add(shift, b)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// permuteOps returns a slice of operators that correspond to the given seed
+// The seed is a base_len(allOps) number, where each digit represents an operator
+func permuteOps(seed int, length int, opsSet []Op) []Op {
+	if seed < 0 || length <= 0 || len(opsSet) == 0 {
+		return nil
+	}
+	opsBase := len(opsSet)
+	ceiling := int(math.Pow(float64(opsBase), float64(length)))
+	if seed > ceiling {
+		return nil // overflow
+	}
+	ops := make([]Op, length)
+	for i := 0; i < length; i++ {
+		allOpsIndex := seed % opsBase
+		seed /= opsBase
+		ops[i] = opsSet[allOpsIndex]
+	}
+	return ops
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Returns the set of operators from opSet that satisfy the Equation
+// Returns nil if none exist, or an error wrapping aoc.ErrOverflow if
+// some set overflowed an int and might have
+func FindOps(e Equation, opsSet []Op) ([]Op, error) {
+	return findOps(e, opsSet, memo.New[opsState, bool](0))
+}
+
+// opsState is a point in the search for operators: the result of applying
+// them to the args before Index
+type opsState struct {
+	Index, Value int
+}
+
+// findOps is FindOps searching depth-first, remembering the states that
+// lead nowhere, and whether they overflowed, in deadEnds.  They only hold
+// for e, so deadEnds must be cleared between equations.
+func findOps(e Equation, opsSet []Op, deadEnds *memo.Cache[opsState, bool]) ([]Op, error) {
+	if len(e.Args) < 2 {
+		return nil, nil // there are no operators to find, as FindOpsBig
+	}
+	growing := e.growing()
+	ops := make([]Op, len(e.Args)-1)
+	var search func(i, value int) (found, overflowed bool)
+	search = func(i, value int) (bool, bool) {
+		if i == len(e.Args) {
+			return value == e.Result, false
+		}
+		state := opsState{i, value}
+		if overflowed, dead := deadEnds.Get(state); dead {
+			return false, overflowed
+		}
+		overflowed := false
+		for _, op := range opsSet {
+			next, ok := op.Apply(value, e.Args[i])
+			if !ok {
+				overflowed = true
+				continue
+			}
+			if growing && next > e.Result {
+				continue // can only grow further from the result
+			}
+			ops[i-1] = op
+			found, over := search(i+1, next)
+			if found {
+				return true, false
+			}
+			overflowed = overflowed || over
+		}
+		deadEnds.Put(state, overflowed)
+		return false, overflowed
+	}
+
+	found, overflowed := search(1, e.Args[0])
+	if found {
+		return ops, nil
+	}
+	if overflowed && !growing {
+		return nil, fmt.Errorf("%w: solving for %d", aoc.ErrOverflow, e.Result)
+	}
+	return nil, nil // once past an int, a growing result can't come back
+}
+
+// FindOpsBig is FindOps computing with math/big, so never overflows
+func FindOpsBig(e Equation, opsSet []Op) []Op {
+	if len(e.Args) == 0 {
+		return nil
+	}
+	want := big.NewInt(int64(e.Result))
+	for i := 0; ; i++ {
+		ops := permuteOps(i, len(e.Args)-1, opsSet)
+		if ops == nil {
+			return nil // we are out of permutations
+		}
+		if result, err := e.CalcBig(ops); err == nil && result.Cmp(want) == 0 {
+			return ops
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// NewEquations parses a string of equations into a slice of Equation structs
+// Returns an error if any line is malformed
+func NewEquations(data string) ([]Equation, error) {
+	var equations []Equation
+	for i, line := range parse.Lines(data) {
+		var equation Equation
+		pair := strings.Split(line, ":")
+		if len(pair) != 2 {
+			return nil, parse.Errorf(i+1, "expected '<result>: <args>'")
+		}
+		var err error
+		if equation.Result, err = parse.Int(i+1, pair[0]); err != nil {
+			return nil, err
+		}
+		if equation.Args, err = parse.Ints(i+1, pair[1]); err != nil {
+			return nil, err
+		}
+		if len(equation.Args) == 0 {
+			return nil, parse.Errorf(i+1, "expected '<result>: <args>', no args")
+		}
+		equations = append(equations, equation)
+	}
+	if len(equations) == 0 {
+		return nil, errors.New("no equations")
+	}
+	return equations, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 7, New: func() aoc.Solver { return &solver{} }, Generate: generate, Big: true})
+}
+
+type solver struct {
+	equations []Equation
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.equations, err = NewEquations(input)
+	return err
+}
+
+// sumSolvable sums the results of the equations solvable with opsSet
+func (s *solver) sumSolvable(ctx context.Context, opsSet []Op) (aoc.Answer, error) {
+	if aoc.Big(ctx) {
+		return s.sumSolvableBig(ctx, opsSet)
+	}
+	sum := 0
+	deadEnds := memo.New[opsState, bool](0)
+	defer func() { aoc.Logger(ctx).Info("memo", "dead_ends", deadEnds.Stats()) }()
+	for _, e := range s.equations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		deadEnds.Clear()
+		ops, err := findOps(e, opsSet, deadEnds)
+		if err != nil {
+			return nil, err
+		}
+		if ops != nil {
+			var ok bool
+			if sum, ok = checked.Add(sum, e.Result); !ok {
+				return nil, fmt.Errorf("%w: summing results", aoc.ErrOverflow)
+			}
+		}
+	}
+	return sum, nil
+}
+
+// sumSolvableBig is sumSolvable computed with math/big
+func (s *solver) sumSolvableBig(ctx context.Context, opsSet []Op) (aoc.Answer, error) {
+	sum := new(big.Int)
+	for _, e := range s.equations {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if ops := FindOpsBig(e, opsSet); ops != nil {
+			sum.Add(sum, big.NewInt(int64(e.Result)))
+		}
+	}
+	return sum, nil
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	return s.sumSolvable(ctx, []Op{AddOp{}, MulOp{}})
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	return s.sumSolvable(ctx, []Op{AddOp{}, MulOp{}, ConcatOp{}})
+}
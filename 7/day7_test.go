@@ -0,0 +1,126 @@
+package day7
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 7) }
+
+func TestGenerated(t *testing.T) { aoctest.Generated(t, 7, 20) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 7, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 7, 2) }
+
+func TestFindOps(t *testing.T) {
+	addMul := []Op{AddOp{}, MulOp{}}
+	all := []Op{AddOp{}, MulOp{}, ConcatOp{}}
+	tests := []struct {
+		e        Equation
+		ops      []Op
+		solvable bool
+	}{
+		{Equation{190, []int{10, 19}}, addMul, true},
+		{Equation{3267, []int{81, 40, 27}}, addMul, true},
+		{Equation{83, []int{17, 5}}, addMul, false},
+		{Equation{156, []int{15, 6}}, addMul, false},
+		{Equation{156, []int{15, 6}}, all, true},
+		{Equation{7290, []int{6, 8, 6, 15}}, all, true},
+		{Equation{192, []int{17, 8, 14}}, all, true},
+		{Equation{21037, []int{9, 7, 18, 13}}, all, false},
+		// a lone arg has no operators to find, even when it is the result
+		{Equation{5, []int{5}}, all, false},
+		{Equation{5, []int{4}}, all, false},
+	}
+	for _, tt := range tests {
+		ops, err := FindOps(tt.e, tt.ops)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bigOps := FindOpsBig(tt.e, tt.ops); (bigOps != nil) != tt.solvable {
+			t.Errorf("FindOpsBig(%v) = %v, want solvable %v", tt.e, bigOps, tt.solvable)
+		}
+		if (ops != nil) != tt.solvable {
+			t.Errorf("FindOps(%v) = %v, want solvable %v", tt.e, ops, tt.solvable)
+			continue
+		}
+		if ops != nil {
+			if got, _ := tt.e.Calc(ops); got != tt.e.Result {
+				t.Errorf("FindOps(%v) ops calculate %d", tt.e, got)
+			}
+		}
+	}
+}
+
+func TestSolveOneArg(t *testing.T) {
+	d, _ := aoc.Lookup(7)
+	input := "5: 5\n7: 3 4\n"
+	if got, err := d.Solve(context.Background(), 1, input); err != nil || fmt.Sprint(got) != "7" {
+		t.Errorf("Solve: got %v, %v, want 7", got, err)
+	}
+	if got, err := d.Solve(aoc.WithBig(context.Background()), 1, input); err != nil || fmt.Sprint(got) != "7" {
+		t.Errorf("Solve with big: got %v, %v, want 7", got, err)
+	}
+}
+
+func TestConcatOp(t *testing.T) {
+	if got, ok := (ConcatOp{}).Apply(12, 345); got != 12345 || !ok {
+		t.Errorf("12 || 345 = %d, %v", got, ok)
+	}
+	if got := (ConcatOp{}).ApplyBig(new(big.Int), big.NewInt(12), big.NewInt(345)); got.Int64() != 12345 {
+		t.Errorf("big 12 || 345 = %v", got)
+	}
+	if _, ok := (ConcatOp{}).Apply(math.MaxInt/100, 345); ok {
+		t.Error("MaxInt/100 || 345 should overflow")
+	}
+}
+
+func TestFindOpsOverflow(t *testing.T) {
+	// multiplying first overflows, but with every arg positive that can't
+	// be the result, so adding is tried next
+	huge := 1 << 40
+	ops, err := FindOps(Equation{2 * huge, []int{huge, huge}}, []Op{MulOp{}, AddOp{}})
+	if err != nil || len(ops) != 1 || ops[0] != (AddOp{}) {
+		t.Errorf("FindOps past an overflow = %v, %v", ops, err)
+	}
+
+	// multiplying by 0 brings any product back down, so one that overflowed
+	// is passed over for one that didn't
+	e := Equation{5, []int{huge, huge, 0, 5}}
+	if ops, err := FindOps(e, []Op{MulOp{}, AddOp{}}); err != nil || ops == nil {
+		t.Errorf("FindOps(%v) = %v, %v", e, ops, err)
+	} else if got, _ := e.Calc(ops); got != 5 {
+		t.Errorf("FindOps(%v) ops calculate %d", e, got)
+	}
+
+	// but when every way overflows, only math/big can tell
+	e = Equation{0, []int{math.MaxInt, 2, 0}}
+	if _, err := FindOps(e, []Op{AddOp{}, MulOp{}}); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("FindOps(%v): got %v, want ErrOverflow", e, err)
+	}
+	if ops := FindOpsBig(e, []Op{AddOp{}, MulOp{}}); ops == nil {
+		t.Errorf("FindOpsBig(%v) found no ops", e)
+	} else if got, _ := e.CalcBig(ops); got.Sign() != 0 {
+		t.Errorf("FindOpsBig(%v) ops calculate %v", e, got)
+	}
+
+	d, _ := aoc.Lookup(7)
+	input := fmt.Sprintf("%d: %d 2 0\n", e.Result, math.MaxInt)
+	if _, err := d.Solve(context.Background(), 1, input); !errors.Is(err, aoc.ErrOverflow) {
+		t.Errorf("Solve: got %v, want ErrOverflow", err)
+	}
+	if got, err := d.Solve(aoc.WithBig(context.Background()), 1, input); err != nil || fmt.Sprint(got) != "0" {
+		t.Errorf("Solve with big: got %v, %v, want 0", got, err)
+	}
+}
+
+func FuzzNewEquations(f *testing.F) {
+	aoctest.Fuzz(f, 7, nil)
+}
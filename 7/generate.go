@@ -0,0 +1,36 @@
+package day7
+
+import (
+	"context"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+)
+
+// generate makes size calibration equations of 2 to 6 small numbers.  Most
+// are solvable with some operators, so all three operators get exercised.
+func generate(_ context.Context, rng *rand.Rand, size int) string {
+	ops := []Op{AddOp{}, MulOp{}, ConcatOp{}}
+	var sb strings.Builder
+	for i := 0; i < size; i++ {
+		args := make([]string, 2+rng.IntN(5))
+		result := 0
+		for j := range args {
+			arg := 1 + rng.IntN(99)
+			args[j] = strconv.Itoa(arg)
+			if j == 0 {
+				result = arg
+			} else {
+				result, _ = ops[rng.IntN(len(ops))].Apply(result, arg) // small enough to fit
+			}
+		}
+		if rng.IntN(4) == 0 {
+			result = 1 + rng.IntN(1000000) // probably not solvable
+		}
+		sb.WriteString(strconv.Itoa(result))
+		sb.WriteString(": ")
+		sb.WriteString(strings.Join(args, " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
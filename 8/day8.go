@@ -0,0 +1,155 @@
+// https://adventofcode.com/2024/day/8
+// aoc2024 run 8 --input 8/8.txt
+
+package day8
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+const (
+	EmptyGlyph    = '.'
+	AntinodeGlyph = '#'
+)
+
+type City struct {
+	puzzle    string
+	antennas  *grid.Grid[byte]
+	antinodes *grid.Grid[byte]
+}
+
+// NewCity parses the antenna map: '.' or an alphanumeric frequency
+func NewCity(puzzle string) (*City, error) {
+	antennas, err := grid.ParseFunc(puzzle, func(c byte) (byte, error) {
+		if c != EmptyGlyph && !isFrequency(c) {
+			return 0, fmt.Errorf("expected '.' or an antenna frequency, got %q", c)
+		}
+		return c, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	c := &City{
+		puzzle:   puzzle,
+		antennas: antennas,
+	}
+	c.ClearAntinodes()
+	return c, nil
+}
+
+func isFrequency(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func (c *City) ClearAntinodes() {
+	c.antinodes = grid.NewLike[byte](c.antennas)
+	c.antinodes.Fill(EmptyGlyph)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func (c *City) setAntinode(pt grid.Point) bool {
+	return c.antinodes.TrySet(pt, AntinodeGlyph)
+}
+
+func (c *City) MarkAntinodes(a, b grid.Point, oneStep bool) {
+	// an antinode occurs at any point that is perfectly in line with
+	// two antennas of the same frequency - but only
+	// when one of the antennas is twice as far away as the other.
+	if !oneStep {
+		// with harmonics enabled, start with antennae themselves
+		c.setAntinode(a)
+		c.setAntinode(b)
+
+	}
+
+	dist := b.Sub(a)
+	for {
+		a = a.Sub(dist)
+		b = b.Add(dist)
+		aInBounds := c.setAntinode(a)
+		bInBounds := c.setAntinode(b)
+		if oneStep || !(aInBounds || bInBounds) {
+			return
+		}
+	}
+
+}
+
+func (c *City) FindAntinodes(oneStep bool) {
+	// march through each antenna, try to find antinodes of all remaining antennas
+	c.ClearAntinodes()
+	antennas := c.antennas.FindAll(func(freq byte) bool { return freq != EmptyGlyph })
+	for i, a := range antennas {
+		freq := c.antennas.At(a)
+		// antennas are row-ordered, so pair with the rest
+		for _, b := range antennas[i+1:] {
+			if c.antennas.At(b) == freq {
+				c.MarkAntinodes(a, b, oneStep)
+			}
+		}
+	}
+}
+
+func (c *City) GetAntinodeCount() int {
+	return c.antinodes.Count(func(c byte) bool { return c != 0 && c != EmptyGlyph })
+}
+
+// AntinodeGrid draws the antinodes, with the antennas over them
+func (c *City) AntinodeGrid() *grid.Grid[byte] {
+	g := c.antinodes.Clone()
+	for pt, antenna := range c.antennas.All() {
+		if antenna != EmptyGlyph {
+			g.Set(pt, antenna)
+		}
+	}
+	return g
+}
+
+func (c *City) View() string {
+	var style = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder())
+	return lipgloss.JoinHorizontal(lipgloss.Left,
+		style.Render(grid.Text(c.antennas)), style.Render(grid.Text(c.antinodes)))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func init() {
+	aoc.Register(aoc.Day{Day: 8, New: func() aoc.Solver { return &solver{} }})
+}
+
+type solver struct {
+	city *City
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
+	if err != nil {
+		return err
+	}
+	s.city, err = NewCity(input)
+	return err
+}
+
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	s.city.FindAntinodes(true) // clears the previous marks, so the city can be reused
+	aoc.Logger(ctx).Debug("antinodes", "city", aoc.Lazy(s.city.View))
+	aoc.Snapshot(ctx, "antinodes", s.city.AntinodeGrid)
+	return s.city.GetAntinodeCount(), nil
+}
+
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	s.city.FindAntinodes(false)
+	aoc.Logger(ctx).Debug("antinodes", "city", aoc.Lazy(s.city.View))
+	aoc.Snapshot(ctx, "antinodes", s.city.AntinodeGrid)
+	return s.city.GetAntinodeCount(), nil
+}
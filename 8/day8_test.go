@@ -0,0 +1,36 @@
+package day8
+
+import (
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 8) }
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 8, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 8, 2) }
+
+func TestFindAntinodes(t *testing.T) {
+	_, err := NewCity("..........\n...#......\n..........\n....a.....\n..........\n.....a....\n..........\n......#...\n..........\n..........")
+	if err == nil {
+		t.Fatal("expected error for '#' in antenna map")
+	}
+	city, err := NewCity("..........\n..........\n..........\n....a.....\n..........\n.....a....\n..........\n..........\n..........\n..........")
+	if err != nil {
+		t.Fatal(err)
+	}
+	city.FindAntinodes(true)
+	if got := city.GetAntinodeCount(); got != 2 {
+		t.Errorf("GetAntinodeCount = %d, want 2", got)
+	}
+}
+
+func FuzzNewCity(f *testing.F) {
+	aoctest.Fuzz(f, 8, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: antinode count %v is negative", part, answer)
+		}
+	})
+}
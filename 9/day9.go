@@ -1,14 +1,20 @@
 // https://adventofcode.com/2024/day/9
-// go run 9/main.go 9/9.txt
+// aoc2024 run 9 --input 9/9.txt
 
-package main
+package day9
 
 import (
+	"context"
 	"fmt"
-	"os"
+	"io"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/replay"
 )
 
 func minOf(x, y int) int {
@@ -32,17 +38,35 @@ type Filesystem struct {
 	puzzle  string
 	diskMap []byte
 	fileMap []int // stores id-1, zero is freespace
+
+	OnStep func() bool // if set, called after each file a defrag moves, e.g. to record it; false stops the defrag
 }
 
-func NewFilesystem(puzzle string) *Filesystem {
+// NewFilesystem parses the disk map, a single line of digits
+func NewFilesystem(puzzle string) (*Filesystem, error) {
+	lines := parse.Lines(puzzle)
+	if len(lines) != 1 {
+		return nil, parse.Errorf(len(lines), "expected one line of digits, got %d lines", len(lines))
+	}
 	filesystem := Filesystem{puzzle: puzzle}
-	// extract all the lines
-	for _, c := range puzzle {
-		filesystem.diskMap = append(filesystem.diskMap, byte(c)-byte('0'))
+	for i, c := range []byte(lines[0]) {
+		if c < '0' || c > '9' {
+			return nil, parse.ErrorfAt(1, i+1, "expected a digit, got %q", c)
+		}
+		filesystem.diskMap = append(filesystem.diskMap, c-'0')
 	}
 	filesystem.makeFileMap()
 
-	return &filesystem
+	return &filesystem, nil
+}
+
+// Clone returns a copy of the filesystem, to defrag without disturbing it
+func (fs *Filesystem) Clone() *Filesystem {
+	return &Filesystem{
+		puzzle:  fs.puzzle,
+		diskMap: slices.Clone(fs.diskMap),
+		fileMap: slices.Clone(fs.fileMap),
+	}
 }
 
 func (fs *Filesystem) makeFileMap() {
@@ -144,6 +168,9 @@ func (fs *Filesystem) DefragWholeFile() {
 				fs.fileMap[freeIndex+j] = fs.fileMap[fileIndex+j]
 				fs.fileMap[fileIndex+j] = 0
 			}
+			if fs.OnStep != nil && !fs.OnStep() {
+				return
+			}
 		}
 	}
 }
@@ -158,23 +185,39 @@ func (fs *Filesystem) DiskMapView() string {
 	return sb.String()
 }
 
+// fileGlyph draws a fileMap block: '.' for free space, else its ID as a
+// byte counting from '0', or '#' where that isn't printable
+func fileGlyph(val int) byte {
+	if val == 0 {
+		return '.'
+	}
+	r := byte(val-1) + '0'
+	if r < 32 || r > 126 {
+		return '#'
+	}
+	return r
+}
+
 func (fs *Filesystem) FileMapView() string {
 	var sb strings.Builder
 	for _, val := range fs.fileMap {
-		if val == 0 {
-			sb.WriteByte('.')
-		} else {
-			r := byte(val-1) + '0'
-			if r < 32 || r > 126 {
-				r = '#'
-			}
-			sb.WriteByte(r)
-		}
+		sb.WriteByte(fileGlyph(val))
 	}
 
 	return sb.String()
 }
 
+// FileMapGrid draws the blocks wrapped into rows of width
+func (fs *Filesystem) FileMapGrid(width int) *grid.Grid[byte] {
+	width = max(min(width, len(fs.fileMap)), 1)
+	g := grid.New[byte](width, (len(fs.fileMap)+width-1)/width)
+	g.Fill(' ')
+	for i, val := range fs.fileMap {
+		g.Set(grid.Point{X: i % width, Y: i / width}, fileGlyph(val))
+	}
+	return g
+}
+
 func (fs *Filesystem) View() string {
 	var style = lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder())
 	return lipgloss.JoinVertical(lipgloss.Left,
@@ -184,27 +227,57 @@ func (fs *Filesystem) View() string {
 
 ///////////////////////////////////////////////////////////////////////////////
 
-func main() {
-	// Open and read data file
-	diskMapData, err := os.ReadFile(os.Args[1])
+func init() {
+	aoc.Register(aoc.Day{Day: 9, New: func() aoc.Solver { return &solver{} }, Replay: replayDefrag})
+}
+
+type solver struct {
+	fs *Filesystem
+}
+
+func (s *solver) Parse(r io.Reader) error {
+	input, err := parse.Read(r)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error reading file: %s\n", err.Error())
-		os.Exit(1)
-	}
-	fs := NewFilesystem(string(diskMapData))
-	if fs == nil {
-		fmt.Fprintf(os.Stderr, "Bad disk map data\n")
-		os.Exit(1)
+		return err
 	}
+	s.fs, err = NewFilesystem(input)
+	return err
+}
 
-	// part 1
+func (s *solver) Part1(ctx context.Context) (aoc.Answer, error) {
+	fs := s.fs.Clone()
 	fs.DefragBlock()
-	fmt.Println("9.1:", fs.CalcChecksum())
+	return fs.CalcChecksum(), nil
+}
 
-	// part 2
-	fs = NewFilesystem(string(diskMapData))
-	//fmt.Println(fs.View())
+func (s *solver) Part2(ctx context.Context) (aoc.Answer, error) {
+	fs := s.fs.Clone()
+	aoc.Logger(ctx).Debug("filesystem", "blocks", aoc.Lazy(fs.View))
 	fs.DefragWholeFile()
-	//fmt.Println(fs.View())
-	fmt.Println("9.2:", fs.CalcChecksum())
+	aoc.Logger(ctx).Debug("defragged whole files", "blocks", aoc.Lazy(fs.View))
+	return fs.CalcChecksum(), nil
+}
+
+// replayWidth is how many blocks a row of the replay shows
+const replayWidth = 100
+
+// replayDefrag records the whole-file defrag, file by file
+func replayDefrag(ctx context.Context, input string, rec *replay.Recorder) error {
+	fs, err := NewFilesystem(input)
+	if err != nil {
+		return err
+	}
+	rec.Record(fs.FileMapGrid(replayWidth), "start")
+	moves := 0
+	fs.OnStep = func() bool {
+		moves++
+		rec.Record(fs.FileMapGrid(replayWidth), fmt.Sprintf("%d files moved", moves))
+		return ctx.Err() == nil
+	}
+	fs.DefragWholeFile()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	rec.Done(fs.FileMapGrid(replayWidth), fmt.Sprintf("%d files moved, checksum %d", moves, fs.CalcChecksum()))
+	return nil
 }
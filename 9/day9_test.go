@@ -0,0 +1,88 @@
+package day9
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/aoc/aoctest"
+	"github.com/neomantra/aoc2024/grid"
+)
+
+func TestGolden(t *testing.T) { aoctest.Golden(t, 9) }
+
+func TestReplay(t *testing.T) {
+	frames := aoctest.Replay(t, 9)
+	last := frames[len(frames)-1]
+	if !strings.HasSuffix(last.Caption, "checksum 2858") {
+		t.Errorf("final caption %q, want the part 2 checksum", last.Caption)
+	}
+	if got := grid.Text(last.Grid); got != "00992111777.44.333....5555.6666.....8888..\n" {
+		t.Errorf("final frame %q", got)
+	}
+}
+
+func BenchmarkPart1(b *testing.B) { aoctest.Benchmark(b, 9, 1) }
+func BenchmarkPart2(b *testing.B) { aoctest.Benchmark(b, 9, 2) }
+
+func TestDefrag(t *testing.T) {
+	fs, err := NewFilesystem("2333133121414131402")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := fs.FileMapView(), "00...111...2...333.44.5555.6666.777.888899"; got != want {
+		t.Errorf("FileMapView = %s, want %s", got, want)
+	}
+	fs.DefragBlock()
+	if got, want := fs.FileMapView(), "0099811188827773336446555566.............."; got != want {
+		t.Errorf("DefragBlock = %s, want %s", got, want)
+	}
+
+	fs, _ = NewFilesystem("2333133121414131402")
+	fs.DefragWholeFile()
+	if got, want := fs.FileMapView(), "00992111777.44.333....5555.6666.....8888.."; got != want {
+		t.Errorf("DefragWholeFile = %s, want %s", got, want)
+	}
+	if got := fs.CalcChecksum(); got != 2858 {
+		t.Errorf("CalcChecksum = %d, want 2858", got)
+	}
+}
+
+func FuzzNewFilesystem(f *testing.F) {
+	aoctest.Fuzz(f, 9, func(t *testing.T, part int, answer aoc.Answer) {
+		if answer.(int) < 0 {
+			t.Errorf("part %d: checksum %v is negative", part, answer)
+		}
+	})
+}
+
+// the checksum is stable, and defragging keeps every file block
+func FuzzDefrag(f *testing.F) {
+	aoctest.Seed(f, 9)
+	f.Fuzz(func(t *testing.T, input string) {
+		fs, err := NewFilesystem(input)
+		if err != nil {
+			return
+		}
+		if a, b := fs.CalcChecksum(), fs.CalcChecksum(); a != b {
+			t.Errorf("checksum changed from %d to %d", a, b)
+		}
+		for _, defrag := range []func(*Filesystem){(*Filesystem).DefragBlock, (*Filesystem).DefragWholeFile} {
+			defragged := fs.Clone()
+			defrag(defragged)
+			if got, want := countFileBlocks(defragged), countFileBlocks(fs); got != want {
+				t.Errorf("defrag left %d file blocks, want %d", got, want)
+			}
+		}
+	})
+}
+
+func countFileBlocks(fs *Filesystem) int {
+	count := 0
+	for _, id := range fs.fileMap {
+		if id != 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,59 @@
+package aoc
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/neomantra/aoc2024/parse"
+)
+
+// Expected is one known answer to a part for an input file.
+type Expected struct {
+	Input  string
+	Part   int
+	Answer string
+	Params Params // for the day's solver, if any
+}
+
+// AnswersPath returns the answers file for day, relative to the day's directory.
+func AnswersPath(day int) string {
+	return fmt.Sprintf("%d.answers.txt", day)
+}
+
+// ReadAnswers parses an answers file.  Each day's directory has one,
+// N/N.answers.txt, listing the expected answer for each example input and
+// part, and any puzzle parameters the example needs:
+//
+//	# input      part  answer  params
+//	6.test.txt   1     41
+//	6.test.txt   2     6
+//	14.test.txt  1     12      room=11x7
+//
+// Blank lines and lines starting with '#' are ignored.
+func ReadAnswers(path string) ([]Expected, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var answers []Expected
+	for i, line := range parse.Lines(string(data)) {
+		if line = strings.TrimSpace(line); line == "" || line[0] == '#' {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			return nil, parse.Named(parse.Errorf(i+1, "expected '<input> <part> <answer> [name=value...]'"), path)
+		}
+		part, err := parse.Int(i+1, fields[1])
+		if err != nil || part < 1 || part > 2 {
+			return nil, parse.Named(parse.Errorf(i+1, "bad part %q", fields[1]), path)
+		}
+		params, err := ParseParams(fields[3:])
+		if err != nil {
+			return nil, parse.Named(parse.Errorf(i+1, "%v", err), path)
+		}
+		answers = append(answers, Expected{Input: fields[0], Part: part, Answer: fields[2], Params: params})
+	}
+	return answers, nil
+}
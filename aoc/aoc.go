@@ -0,0 +1,283 @@
+// Package aoc is the registry of Advent of Code 2024 solvers.
+//
+// Each day's package registers itself from an init function, and the
+// aoc2024 command imports them all so every day is reachable from one binary.
+package aoc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+// Answer is the result of solving one part of a puzzle.
+type Answer any
+
+// SnapshotFunc receives a named picture of a solver's state.
+type SnapshotFunc func(name string, g *grid.Grid[byte])
+
+type snapshotKey struct{}
+
+// WithSnapshots returns a context whose solvers send their snapshots to fn,
+// e.g. to export them as images.
+func WithSnapshots(ctx context.Context, fn SnapshotFunc) context.Context {
+	return context.WithValue(ctx, snapshotKey{}, fn)
+}
+
+// Snapshot sends the grid draw returns, named like "path", to ctx's
+// SnapshotFunc.  draw is only called if a runner wants snapshots.
+func Snapshot(ctx context.Context, name string, draw func() *grid.Grid[byte]) {
+	if fn, ok := ctx.Value(snapshotKey{}).(SnapshotFunc); ok && fn != nil {
+		fn(name, draw())
+	}
+}
+
+// SolveHook wraps solving one part, e.g. to profile it: it is called just
+// before the part is solved, and the function it returns just after.
+type SolveHook func(day, part int) (done func())
+
+type solveHookKey struct{}
+
+// WithSolveHook returns a context whose Runs call hook around each part.
+func WithSolveHook(ctx context.Context, hook SolveHook) context.Context {
+	return context.WithValue(ctx, solveHookKey{}, hook)
+}
+
+// Solver solves one day's puzzle.  Parse reads the input once, then either
+// part may be solved, in any order, as often as wanted; parts must not
+// disturb the parsed state.  Long-running parts give up when ctx is done,
+// returning ctx.Err().
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1(ctx context.Context) (Answer, error)
+	Part2(ctx context.Context) (Answer, error)
+}
+
+// Part1Only can be embedded in a Solver that has no part 2 yet.
+type Part1Only struct{}
+
+func (Part1Only) Part2(context.Context) (Answer, error) { return nil, ErrNotImplemented }
+
+func (Part1Only) part1Only() {}
+
+// Day describes a registered day.
+type Day struct {
+	Day int
+	New func() Solver
+
+	// Interactive is an optional long-running mode (a TUI, an external service...)
+	Interactive func(ctx context.Context, input string) error
+
+	// Generate optionally synthesises random valid inputs, for stress testing
+	Generate Generator
+
+	// Replay optionally records a simulation of input, frame by frame,
+	// giving up when ctx is done
+	Replay func(ctx context.Context, input string, rec *replay.Recorder) error
+
+	// Big is set if the solver computes with math/big under WithBig, where
+	// it would otherwise fail with ErrOverflow
+	Big bool
+}
+
+// Generator synthesises a random, valid puzzle input from rng.  size scales
+// it, roughly as the number of lines or the width of a grid.  It is valid
+// for the solver configured with ctx's params.
+type Generator func(ctx context.Context, rng *rand.Rand, size int) string
+
+// NewRand returns the random source generators use for seed.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, 0x2024))
+}
+
+// ErrNotImplemented is returned when a part has no solver.
+var ErrNotImplemented = errors.New("not implemented")
+
+// HasPart reports whether the day solves part (1 or 2).
+func (d *Day) HasPart(part int) bool {
+	switch part {
+	case 1:
+		return true
+	case 2:
+		_, only := d.New().(interface{ part1Only() })
+		return !only
+	default:
+		return false
+	}
+}
+
+// SolvePart solves one part with an already-parsed solver.
+func SolvePart(ctx context.Context, s Solver, part int) (Answer, error) {
+	ctx = withLogAttrs(ctx, "part", part)
+	switch part {
+	case 1:
+		return s.Part1(ctx)
+	case 2:
+		return s.Part2(ctx)
+	default:
+		return nil, ErrNotImplemented
+	}
+}
+
+// Solve parses input and solves one part of it, with ctx's Params.
+func (d *Day) Solve(ctx context.Context, part int, input string) (Answer, error) {
+	ctx = withLogAttrs(ctx, "day", d.Day)
+	s, err := d.newSolver(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Parse(strings.NewReader(input)); err != nil {
+		return nil, err
+	}
+	return SolvePart(ctx, s, part)
+}
+
+// Result is the outcome of solving one part.
+type Result struct {
+	Day     int
+	Part    int
+	Input   string // name of the input, if known
+	Answer  Answer
+	Elapsed time.Duration // solving time, excluding parsing
+	Err     error
+}
+
+// Run parses input once and solves each of parts in turn, timing each,
+// within ctx's SolveHook if it has one.
+// The solver gets ctx's Params; a bad parameter or parse error is the error
+// of every part.  Panics are recovered as a
+// *PanicError, so one broken day cannot take down a whole run.
+func (d *Day) Run(ctx context.Context, input string, parts ...int) []Result {
+	results := make([]Result, len(parts))
+	ctx = withLogAttrs(ctx, "day", d.Day)
+	s, parseErr := d.newSolver(ctx)
+	if parseErr == nil {
+		parseErr = protect(func() error { return s.Parse(strings.NewReader(input)) })
+	}
+	hook, _ := ctx.Value(solveHookKey{}).(SolveHook)
+	for i, part := range parts {
+		r := Result{Day: d.Day, Part: part, Err: parseErr}
+		if parseErr == nil {
+			done := func() {}
+			if hook != nil {
+				done = hook(d.Day, part)
+			}
+			start := time.Now()
+			r.Err = protect(func() (err error) {
+				r.Answer, err = SolvePart(ctx, s, part)
+				return err
+			})
+			r.Elapsed = time.Since(start)
+			done()
+			if errors.Is(r.Err, context.DeadlineExceeded) {
+				r.Err = fmt.Errorf("timed out after %s: %w", r.Elapsed.Round(time.Millisecond), r.Err)
+			}
+		}
+		results[i] = r
+	}
+	return results
+}
+
+// PanicError is a panic recovered from a solver.
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string { return fmt.Sprintf("panic: %v", e.Value) }
+
+// protect calls fn, recovering a panic as a *PanicError
+func protect(fn func() error) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &PanicError{Value: v, Stack: debug.Stack()}
+		}
+	}()
+	return fn()
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+var registry = make(map[int]*Day)
+
+// Register adds a day to the registry.  It panics on a bad or duplicate day,
+// since that is a programming error caught at init.
+func Register(d Day) {
+	if d.Day < 1 || d.Day > 25 {
+		panic(fmt.Sprintf("aoc: invalid day %d", d.Day))
+	}
+	if d.New == nil {
+		panic(fmt.Sprintf("aoc: day %d has no solver", d.Day))
+	}
+	if _, ok := registry[d.Day]; ok {
+		panic(fmt.Sprintf("aoc: day %d registered twice", d.Day))
+	}
+	registry[d.Day] = &d
+}
+
+// Lookup returns the registered day, or false if there isn't one.
+func Lookup(day int) (*Day, bool) {
+	d, ok := registry[day]
+	return d, ok
+}
+
+// Days returns all registered days in order.
+func Days() []*Day {
+	days := make([]*Day, 0, len(registry))
+	for _, d := range registry {
+		days = append(days, d)
+	}
+	slices.SortFunc(days, func(a, b *Day) int { return a.Day - b.Day })
+	return days
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Select returns the registered days matching spec, in order.
+// A spec is a comma-separated list of days and ranges, like "6" or "1-5,7".
+// Days named explicitly must be registered; unregistered days inside a
+// range are skipped.
+func Select(spec string) ([]*Day, error) {
+	wanted := make(map[int]bool)
+	for _, field := range strings.Split(spec, ",") {
+		lo, hi, isRange := strings.Cut(strings.TrimSpace(field), "-")
+		first, err := strconv.Atoi(lo)
+		if err != nil {
+			return nil, fmt.Errorf("bad day %q", field)
+		}
+		last := first
+		if isRange {
+			if last, err = strconv.Atoi(hi); err != nil || last < first {
+				return nil, fmt.Errorf("bad day range %q", field)
+			}
+		}
+		for day := first; day <= last; day++ {
+			if _, ok := registry[day]; ok {
+				wanted[day] = true
+			} else if !isRange {
+				return nil, fmt.Errorf("day %d is not registered", day)
+			}
+		}
+	}
+
+	var days []*Day
+	for _, d := range Days() {
+		if wanted[d.Day] {
+			days = append(days, d)
+		}
+	}
+	if len(days) == 0 {
+		return nil, fmt.Errorf("no registered days in %q", spec)
+	}
+	return days, nil
+}
@@ -0,0 +1,267 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// echoSolver answers part 1 with its input, and spins in part 2 until cancelled
+type echoSolver struct {
+	input  string
+	parses int
+}
+
+func (s *echoSolver) Parse(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	if strings.Contains(string(data), "bad") {
+		return errors.New("bad input")
+	}
+	s.input = string(data)
+	s.parses++
+	return err
+}
+
+func (s *echoSolver) Part1(ctx context.Context) (Answer, error) { return s.input, nil }
+
+func (s *echoSolver) Part2(ctx context.Context) (Answer, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type part1Solver struct{ Part1Only }
+
+func (s *part1Solver) Parse(r io.Reader) error                   { return nil }
+func (s *part1Solver) Part1(ctx context.Context) (Answer, error) { return 1, nil }
+
+type panicSolver struct{ Part1Only }
+
+func (s *panicSolver) Parse(r io.Reader) error                   { return nil }
+func (s *panicSolver) Part1(ctx context.Context) (Answer, error) { panic("oops") }
+
+// repeatSolver answers part 1 with its input repeated, a parameter
+type repeatSolver struct {
+	Part1Only
+	input string
+	times int
+}
+
+func (s *repeatSolver) DefineParams(fs *flag.FlagSet) {
+	fs.IntVar(&s.times, "times", s.times, "repeats")
+}
+
+func (s *repeatSolver) Parse(r io.Reader) error {
+	data, err := io.ReadAll(r)
+	s.input = string(data)
+	return err
+}
+
+func (s *repeatSolver) Part1(ctx context.Context) (Answer, error) {
+	return strings.Repeat(s.input, s.times), nil
+}
+
+func TestSelect(t *testing.T) {
+	registry = map[int]*Day{}
+	for _, day := range []int{1, 2, 3, 5, 17} {
+		Register(Day{Day: day, New: func() Solver { return &echoSolver{} }})
+	}
+	tests := []struct {
+		spec    string
+		want    []int
+		wantErr bool
+	}{
+		{"1", []int{1}, false},
+		{"1-17", []int{1, 2, 3, 5, 17}, false},
+		{"3,1-2", []int{1, 2, 3}, false},
+		{"4", nil, true},
+		{"6-10", nil, true},
+		{"5-1", nil, true},
+		{"x", nil, true},
+	}
+	for _, tt := range tests {
+		days, err := Select(tt.spec)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("Select(%q) error = %v, wantErr %v", tt.spec, err, tt.wantErr)
+			continue
+		}
+		if len(days) != len(tt.want) {
+			t.Errorf("Select(%q) = %d days, want %v", tt.spec, len(days), tt.want)
+			continue
+		}
+		for i, d := range days {
+			if d.Day != tt.want[i] {
+				t.Errorf("Select(%q)[%d] = %d, want %d", tt.spec, i, d.Day, tt.want[i])
+			}
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	var s *echoSolver
+	d := Day{Day: 1, New: func() Solver { s = &echoSolver{}; return s }}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	results := d.Run(ctx, "hello", 1, 2)
+	if s.parses != 1 {
+		t.Errorf("parsed %d times, want once", s.parses)
+	}
+	if r := results[0]; r.Part != 1 || r.Answer != "hello" || r.Err != nil {
+		t.Errorf("part 1: got %+v", r)
+	}
+	if r := results[1]; !errors.Is(r.Err, context.DeadlineExceeded) || !strings.HasPrefix(r.Err.Error(), "timed out") {
+		t.Errorf("part 2: got %+v, want a timeout", r)
+	}
+
+	for _, r := range d.Run(context.Background(), "bad", 1, 2) {
+		if r.Err == nil || r.Err.Error() != "bad input" {
+			t.Errorf("part %d: got %v, want the parse error", r.Part, r.Err)
+		}
+	}
+
+	panicky := Day{Day: 3, New: func() Solver { return &panicSolver{} }}
+	var pe *PanicError
+	if r := panicky.Run(context.Background(), "", 1)[0]; !errors.As(r.Err, &pe) {
+		t.Errorf("panicking part: got %v, want a *PanicError", r.Err)
+	}
+
+	if !d.HasPart(2) {
+		t.Error("HasPart(2) = false, want true")
+	}
+	only := Day{Day: 2, New: func() Solver { return &part1Solver{} }}
+	if only.HasPart(2) {
+		t.Error("Part1Only: HasPart(2) = true, want false")
+	}
+	if _, err := only.Solve(context.Background(), 2, ""); err != ErrNotImplemented {
+		t.Errorf("Part1Only: got %v, want ErrNotImplemented", err)
+	}
+}
+
+func TestShrink(t *testing.T) {
+	// fails while any number is at least 10
+	fails := func(input string) bool {
+		for _, field := range strings.Fields(input) {
+			if n, err := strconv.Atoi(field); err == nil && n >= 10 {
+				return true
+			}
+		}
+		return false
+	}
+	if got := Shrink("1 2\n\n30 4 5\n6\n\n7 8\n", fails); got != "15" {
+		t.Errorf("Shrink = %q, want %q", got, "15")
+	}
+
+	d := Day{Day: 1, New: func() Solver { return &echoSolver{} }}
+	if d.CanVerify() {
+		t.Error("CanVerify() = true for a solver without Verify")
+	}
+	if err := d.Verify(context.Background(), "hello"); err != ErrNotImplemented {
+		t.Errorf("Verify: got %v, want ErrNotImplemented", err)
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	draw := func() *grid.Grid[byte] { return grid.New[byte](2, 1) }
+	Snapshot(context.Background(), "ignored", func() *grid.Grid[byte] {
+		t.Error("drew a snapshot nobody wanted")
+		return nil
+	})
+
+	var names []string
+	ctx := WithSnapshots(context.Background(), func(name string, g *grid.Grid[byte]) {
+		names = append(names, fmt.Sprint(name, g.Extent()))
+	})
+	Snapshot(ctx, "path", draw)
+	if len(names) != 1 || names[0] != "path(2,1)" {
+		t.Errorf("got snapshots %v", names)
+	}
+}
+
+func TestSolveHook(t *testing.T) {
+	var calls []string
+	ctx := WithSolveHook(context.Background(), func(day, part int) func() {
+		calls = append(calls, fmt.Sprintf("start %d.%d", day, part))
+		return func() { calls = append(calls, fmt.Sprintf("done %d.%d", day, part)) }
+	})
+
+	panicky := Day{Day: 3, New: func() Solver { return &panicSolver{} }}
+	panicky.Run(ctx, "", 1)
+	d := Day{Day: 1, New: func() Solver { return &echoSolver{} }}
+	d.Run(ctx, "bad", 1) // no solving, no hook
+	want := []string{"start 3.1", "done 3.1"}
+	if !slices.Equal(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+}
+
+func TestParams(t *testing.T) {
+	d := Day{Day: 5, New: func() Solver { return &repeatSolver{times: 2} }}
+	if fs := d.ParamFlags(); fs == nil || fs.Lookup("times").DefValue != "2" {
+		t.Errorf("ParamFlags: got %v, want times defaulting to 2", fs)
+	}
+
+	params, err := ParseParams([]string{"times=3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, err := d.Solve(context.Background(), 1, "ab"); got != "abab" || err != nil {
+		t.Errorf("default params: got %v, %v", got, err)
+	}
+	r := d.Run(WithParams(context.Background(), params), "ab", 1)[0]
+	if r.Answer != "ababab" || r.Err != nil {
+		t.Errorf("times=3: got %+v", r)
+	}
+	s := &repeatSolver{times: 2}
+	if err := Configure(WithParams(context.Background(), params), 5, s); err != nil || s.times != 3 {
+		t.Errorf("Configure: got times %d, %v, want 3", s.times, err)
+	}
+
+	for _, params := range []Params{{"times": "x"}, {"speed": "1"}} {
+		if _, err := d.NewSolver(params); err == nil {
+			t.Errorf("NewSolver(%v): want an error", params)
+		}
+	}
+	plain := Day{Day: 2, New: func() Solver { return &part1Solver{} }}
+	if plain.ParamFlags() != nil {
+		t.Error("ParamFlags of a day without parameters: want nil")
+	}
+	if _, err := plain.NewSolver(params); err == nil {
+		t.Error("NewSolver with params for a day without any: want an error")
+	}
+	if _, err := ParseParams([]string{"times"}); err == nil {
+		t.Error("ParseParams(times): want an error")
+	}
+}
+
+func TestLogger(t *testing.T) {
+	var buf strings.Builder
+	saved := Log
+	defer func() { Log = saved }()
+	Log = slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	d := Day{Day: 7, New: func() Solver { return &logSolver{} }}
+	d.Run(context.Background(), "", 1)
+	if got := buf.String(); !strings.Contains(got, "msg=solving day=7 part=1 board=\"#.\\n.#\"") {
+		t.Errorf("got log %q, want the record tagged with day and part", got)
+	}
+}
+
+// logSolver logs a board at debug level in part 1
+type logSolver struct{ Part1Only }
+
+func (s *logSolver) Parse(r io.Reader) error { return nil }
+
+func (s *logSolver) Part1(ctx context.Context) (Answer, error) {
+	Logger(ctx).Debug("solving", "board", Lazy(func() string { return "#.\n.#" }))
+	return 0, nil
+}
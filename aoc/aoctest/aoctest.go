@@ -0,0 +1,321 @@
+// Package aoctest checks registered solvers against known example answers,
+// from each day's answers file (see aoc.ReadAnswers), and benchmarks them.
+package aoctest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+// Golden runs every expected answer for day through its registered solvers,
+// and again computing with math/big if the day is Big.
+// It must be called from the day's package directory, as `go test` does.
+func Golden(t *testing.T, day int) {
+	t.Helper()
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		t.Fatalf("day %d is not registered", day)
+	}
+	answers, err := aoc.ReadAnswers(aoc.AnswersPath(day))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) == 0 {
+		t.Fatalf("no answers in %s", aoc.AnswersPath(day))
+	}
+	for _, want := range answers {
+		t.Run(fmt.Sprintf("%s/part%d", want.Input, want.Part), func(t *testing.T) {
+			input, err := os.ReadFile(want.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := d.Solve(aoc.WithParams(context.Background(), want.Params), want.Part, string(input))
+			if err != nil {
+				t.Fatal(parse.Named(err, want.Input))
+			}
+			if fmt.Sprint(got) != want.Answer {
+				t.Errorf("day %d part %d on %s: got %v, want %s",
+					day, want.Part, want.Input, got, want.Answer)
+			}
+			if !d.Big {
+				return
+			}
+			got, err = d.Solve(aoc.WithBig(aoc.WithParams(context.Background(), want.Params)), want.Part, string(input))
+			if err != nil {
+				t.Fatal(parse.Named(err, want.Input))
+			}
+			if fmt.Sprint(got) != want.Answer {
+				t.Errorf("day %d part %d on %s with big: got %v, want %s",
+					day, want.Part, want.Input, got, want.Answer)
+			}
+		})
+	}
+
+	// parts share one parsed solver, so solving them in any order, again and
+	// again, must give the same answers
+	byInput := make(map[string][]aoc.Expected) // by input and params
+	var inputs []string
+	for _, want := range answers {
+		key := want.Input
+		if len(want.Params) > 0 {
+			key += " " + want.Params.String()
+		}
+		if byInput[key] == nil {
+			inputs = append(inputs, key)
+		}
+		byInput[key] = append(byInput[key], want)
+	}
+	for _, key := range inputs {
+		wants := byInput[key]
+		input := wants[0].Input
+		t.Run(input+"/shared", func(t *testing.T) {
+			data, err := os.ReadFile(input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			s, err := d.NewSolver(wants[0].Params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Parse(strings.NewReader(string(data))); err != nil {
+				t.Fatal(parse.Named(err, input))
+			}
+			order := append(slices.Clone(wants), wants...)
+			slices.Reverse(order[:len(wants)])
+			for _, want := range order {
+				got, err := aoc.SolvePart(context.Background(), s, want.Part)
+				if err != nil {
+					t.Fatal(parse.Named(err, input))
+				}
+				if fmt.Sprint(got) != want.Answer {
+					t.Errorf("day %d part %d on %s, reusing the solver: got %v, want %s",
+						day, want.Part, input, got, want.Answer)
+				}
+			}
+		})
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Generated solves n generated inputs for day, of growing size, failing on
+// any error or panic.  Days with a Verifier also cross-check each input,
+// reporting the smallest input that still disagrees.  Failures name the
+// seed and size, to reproduce with `aoc2024 gen`.
+func Generated(t *testing.T, day, n int) {
+	t.Helper()
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		t.Fatalf("day %d is not registered", day)
+	}
+	if d.Generate == nil {
+		t.Fatalf("day %d has no generator", day)
+	}
+	if testing.Short() {
+		n = min(n, 3)
+	}
+	for seed := uint64(1); seed <= uint64(n); seed++ {
+		size := 4 + int(seed)
+		input := d.Generate(context.Background(), aoc.NewRand(seed), size)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		for _, r := range d.Run(ctx, input, 1, 2) {
+			if r.Err != nil && r.Err != aoc.ErrNotImplemented {
+				t.Errorf("day %d part %d, seed %d size %d: %v", day, r.Part, seed, size, r.Err)
+			}
+		}
+		if d.CanVerify() {
+			if err := d.Verify(ctx, input); err != nil {
+				t.Errorf("day %d verify, seed %d size %d: %v", day, seed, size, err)
+				var m *aoc.Mismatch
+				if errors.As(err, &m) {
+					t.Logf("smallest disagreeing input:\n%s", aoc.Shrink(input, func(input string) bool {
+						return errors.As(d.Verify(ctx, input), &m)
+					}))
+				}
+			}
+		}
+		cancel()
+	}
+}
+
+// Check verifies invariants of an answer to a part.
+type Check func(t *testing.T, part int, answer aoc.Answer)
+
+// Seed adds day's example inputs, and a few generated ones, to f's corpus.
+func Seed(f *testing.F, day int) {
+	f.Helper()
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		f.Fatalf("day %d is not registered", day)
+	}
+	answers, err := aoc.ReadAnswers(aoc.AnswersPath(day))
+	if err != nil && !os.IsNotExist(err) {
+		f.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, want := range answers {
+		if seen[want.Input] {
+			continue
+		}
+		seen[want.Input] = true
+		data, err := os.ReadFile(want.Input)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(string(data))
+	}
+	if d.Generate != nil {
+		for seed := uint64(1); seed <= 3; seed++ {
+			f.Add(d.Generate(context.Background(), aoc.NewRand(seed), 5))
+		}
+	}
+}
+
+// Fuzz fuzzes day's parser and solvers from the Seed corpus.  Parsing may
+// fail but must not panic; inputs that parse must solve without panicking,
+// and check, if not nil, verifies each answer.  Solvers get a second per
+// part, as fuzzed inputs can be pathological.
+func Fuzz(f *testing.F, day int, check Check) {
+	f.Helper()
+	Seed(f, day)
+	d, _ := aoc.Lookup(day)
+	f.Fuzz(func(t *testing.T, input string) {
+		s := d.New()
+		if err := s.Parse(strings.NewReader(input)); err != nil {
+			return
+		}
+		for part := 1; part <= 2; part++ {
+			if !d.HasPart(part) {
+				continue
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			answer, err := aoc.SolvePart(ctx, s, part)
+			cancel()
+			if err == nil && check != nil {
+				check(t, part, answer)
+			}
+		}
+	})
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Replay records day's simulation of its example N.test.txt, with the
+// example's params from the answers file, and returns its frames, checking they are in step order and all the same size, and that a
+// done context stops it.
+// It must be called from the day's package directory, as `go test` does.
+func Replay(t *testing.T, day int) []replay.Frame {
+	t.Helper()
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		t.Fatalf("day %d is not registered", day)
+	}
+	if d.Replay == nil {
+		t.Fatalf("day %d has no replay", day)
+	}
+	path := fmt.Sprintf("%d.test.txt", day)
+	input, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	answers, err := aoc.ReadAnswers(aoc.AnswersPath(day))
+	if err != nil && !os.IsNotExist(err) {
+		t.Fatal(err)
+	}
+	for _, want := range answers {
+		if want.Input == path {
+			ctx = aoc.WithParams(ctx, want.Params) // as the example is solved
+			break
+		}
+	}
+	var rec replay.Recorder
+	if err := d.Replay(ctx, string(input), &rec); err != nil {
+		t.Fatal(parse.Named(err, path))
+	}
+	frames := rec.Frames()
+	if len(frames) == 0 {
+		t.Fatalf("day %d replay has no frames", day)
+	}
+	for i, f := range frames {
+		if i > 0 && f.Step <= frames[i-1].Step {
+			t.Errorf("day %d replay frame %d is step %d, after step %d", day, i, f.Step, frames[i-1].Step)
+		}
+		if f.Grid.Extent() != frames[0].Grid.Extent() {
+			t.Errorf("day %d replay frame %d is %v, unlike the first frame's %v", day, i, f.Grid.Extent(), frames[0].Grid.Extent())
+		}
+	}
+
+	// a done ctx stops the simulation
+	ctx, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := d.Replay(ctx, string(input), &replay.Recorder{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("day %d replay with a cancelled context: got %v, want context.Canceled", day, err)
+	}
+	return frames
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// BenchInput returns the input file to benchmark a part with, relative to the
+// day's directory, and its params: the real puzzle N.txt if present,
+// otherwise the first example listed for that part in the answers file.
+func BenchInput(day, part int) (string, aoc.Params, error) {
+	if puzzle := fmt.Sprintf("%d.txt", day); fileExists(puzzle) {
+		return puzzle, nil, nil
+	}
+	answers, err := aoc.ReadAnswers(aoc.AnswersPath(day))
+	if err != nil {
+		return "", nil, err
+	}
+	for _, want := range answers {
+		if want.Part == part {
+			return want.Input, want.Params, nil
+		}
+	}
+	return "", nil, fmt.Errorf("no input for day %d part %d", day, part)
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// Benchmark benchmarks one part of day against its BenchInput.
+// It must be called from the day's package directory, as `go test` does.
+func Benchmark(b *testing.B, day, part int) {
+	b.Helper()
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		b.Fatalf("day %d is not registered", day)
+	}
+	if !d.HasPart(part) {
+		b.Skipf("day %d part %d is not implemented", day, part)
+	}
+	path, params, err := BenchInput(day, part)
+	if err != nil {
+		b.Skip(err)
+	}
+	input, err := os.ReadFile(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	ctx := aoc.WithParams(context.Background(), params)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.Solve(ctx, part, string(input)); err != nil {
+			b.Fatal(parse.Named(err, path))
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package aoc
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrOverflow is returned by solvers whose numbers outgrew an int, rather
+// than a wrapped-around answer.  Days with Big set can solve it exactly
+// with WithBig.
+var ErrOverflow = errors.New("integer overflow")
+
+type bigKey struct{}
+
+// WithBig returns a context whose solvers of Big days compute with
+// math/big where an int could overflow: slower, but exact.  Their answers
+// are then *big.Int.
+func WithBig(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bigKey{}, true)
+}
+
+// Big reports whether ctx asks solvers to compute with math/big.
+func Big(ctx context.Context) bool {
+	big, _ := ctx.Value(bigKey{}).(bool)
+	return big
+}
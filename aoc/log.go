@@ -0,0 +1,49 @@
+package aoc
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Log is where solvers' diagnostics go, through Logger: boards and other
+// intermediate state at debug level, notable findings at info level.
+// It discards everything until a runner sets it.
+var Log = slog.New(discardHandler{})
+
+type loggerKey struct{}
+
+// Logger returns ctx's logger, which Run, Solve and Verify tag with the day
+// and SolvePart with the part, or Log.
+func Logger(ctx context.Context) *slog.Logger {
+	if l, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return l
+	}
+	return Log
+}
+
+// withLogAttrs returns ctx with its logger tagged with args
+func withLogAttrs(ctx context.Context, args ...any) context.Context {
+	l := Logger(ctx)
+	if _, discard := l.Handler().(discardHandler); discard {
+		return ctx
+	}
+	return context.WithValue(ctx, loggerKey{}, l.With(args...))
+}
+
+// Lazy is a log value computed only if it is logged, for expensive ones
+// like boards:
+//
+//	aoc.Logger(ctx).Debug("warehouse", "map", aoc.Lazy(w.View))
+type Lazy func() string
+
+func (f Lazy) LogValue() slog.Value {
+	return slog.StringValue(f())
+}
+
+// discardHandler drops every record
+type discardHandler struct{}
+
+func (discardHandler) Enabled(context.Context, slog.Level) bool  { return false }
+func (discardHandler) Handle(context.Context, slog.Record) error { return nil }
+func (h discardHandler) WithAttrs([]slog.Attr) slog.Handler      { return h }
+func (h discardHandler) WithGroup(string) slog.Handler           { return h }
@@ -0,0 +1,111 @@
+package aoc
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Params are values of a day's puzzle parameters, by name, e.g.
+// {"room": "11x7"} for day 14's example.
+type Params map[string]string
+
+// ParseParams parses "name=value" pairs.
+func ParseParams(pairs []string) (Params, error) {
+	params := make(Params, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("bad parameter %q, expected name=value", pair)
+		}
+		params[name] = value
+	}
+	return params, nil
+}
+
+// String formats params as sorted "name=value" pairs.
+func (p Params) String() string {
+	pairs := make([]string, 0, len(p))
+	for _, name := range slices.Sorted(maps.Keys(p)) {
+		pairs = append(pairs, name+"="+p[name])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// Configurable is implemented by solvers of puzzles with parameters, like
+// day 14's room size, that differ between the examples and the real input.
+// DefineParams defines them as flags on fs, bound to the solver's fields and
+// defaulting to their current values, so New must set the real puzzle's.
+type Configurable interface {
+	DefineParams(fs *flag.FlagSet)
+}
+
+type paramsKey struct{}
+
+// WithParams returns a context whose Runs, Solves and Verifies configure
+// their solvers with params.
+func WithParams(ctx context.Context, params Params) context.Context {
+	return context.WithValue(ctx, paramsKey{}, params)
+}
+
+// ParamFlags returns the day's parameters, with their defaults, or nil if
+// it has none.
+func (d *Day) ParamFlags() *flag.FlagSet {
+	c, ok := d.New().(Configurable)
+	if !ok {
+		return nil
+	}
+	fs := flag.NewFlagSet(fmt.Sprintf("day %d", d.Day), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	c.DefineParams(fs)
+	return fs
+}
+
+// NewSolver returns a new solver configured with params.  It is an error
+// to set a parameter the day doesn't have.
+func (d *Day) NewSolver(params Params) (Solver, error) {
+	s := d.New()
+	if err := configure(d.Day, s, params); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Configure sets the parameters of day's solver s from ctx's params, for
+// the modes besides solving, like Replay and Interactive, that use one.
+func Configure(ctx context.Context, day int, s Solver) error {
+	params, _ := ctx.Value(paramsKey{}).(Params)
+	return configure(day, s, params)
+}
+
+func configure(day int, s Solver, params Params) error {
+	if len(params) == 0 {
+		return nil
+	}
+	c, ok := s.(Configurable)
+	if !ok {
+		return fmt.Errorf("day %d has no parameters", day)
+	}
+	fs := flag.NewFlagSet(fmt.Sprintf("day %d", day), flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	c.DefineParams(fs)
+	for _, name := range slices.Sorted(maps.Keys(params)) {
+		if fs.Lookup(name) == nil {
+			return fmt.Errorf("day %d has no parameter %q", day, name)
+		}
+		if err := fs.Set(name, params[name]); err != nil {
+			return fmt.Errorf("day %d parameter %s: %w", day, name, err)
+		}
+	}
+	return nil
+}
+
+// newSolver returns a new solver configured with ctx's params
+func (d *Day) newSolver(ctx context.Context) (Solver, error) {
+	params, _ := ctx.Value(paramsKey{}).(Params)
+	return d.NewSolver(params)
+}
@@ -0,0 +1,143 @@
+package aoc
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Verifier is implemented by solvers that have both a naive and a fast
+// implementation of something, to cross-check them on the parsed input.
+// Verify returns a *Mismatch at the first disagreement.
+type Verifier interface {
+	Verify(ctx context.Context) error
+}
+
+// Mismatch is a disagreement between a fast and a naive implementation.
+type Mismatch struct {
+	What  string // what was compared, e.g. "game 3" or "stones after 5 blinks"
+	Fast  any
+	Naive any
+}
+
+func (m *Mismatch) Error() string {
+	return fmt.Sprintf("%s: fast %v, naive %v", m.What, m.Fast, m.Naive)
+}
+
+// CanVerify reports whether the day's solver is a Verifier.
+func (d *Day) CanVerify() bool {
+	_, ok := d.New().(Verifier)
+	return ok
+}
+
+// Verify parses input and cross-checks the day's implementations on it.
+// It returns a *Mismatch if they disagree, ErrNotImplemented if the day
+// has no Verifier, and otherwise any parse or verify error; panics are
+// recovered as a *PanicError.
+func (d *Day) Verify(ctx context.Context, input string) error {
+	ctx = withLogAttrs(ctx, "day", d.Day)
+	s, err := d.newSolver(ctx)
+	if err != nil {
+		return err
+	}
+	v, ok := s.(Verifier)
+	if !ok {
+		return ErrNotImplemented
+	}
+	if err := protect(func() error { return s.Parse(strings.NewReader(input)) }); err != nil {
+		return err
+	}
+	return protect(func() error { return v.Verify(ctx) })
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Shrink reduces input for as long as fails still holds of it, and returns
+// the smallest input found; fails must hold of input itself.
+// It drops blank-line separated sections, then lines, then whitespace
+// separated fields within lines, then moves integers toward zero.
+func Shrink(input string, fails func(string) bool) string {
+	input = shrinkSplit(input, "\n\n", fails)
+	input = shrinkSplit(input, "\n", fails)
+
+	lines := strings.Split(input, "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		kept := ddmin(fields, func(fields []string) bool {
+			lines[i] = strings.Join(fields, " ")
+			defer func() { lines[i] = line }()
+			return fails(strings.Join(lines, "\n"))
+		})
+		if len(kept) < len(fields) {
+			lines[i] = strings.Join(kept, " ")
+			line = lines[i]
+		}
+	}
+	input = strings.Join(lines, "\n")
+
+	return shrinkInts(input, fails)
+}
+
+// shrinkSplit drops pieces of input separated by sep
+func shrinkSplit(input, sep string, fails func(string) bool) string {
+	pieces := strings.Split(input, sep)
+	kept := ddmin(pieces, func(pieces []string) bool {
+		return fails(strings.Join(pieces, sep))
+	})
+	return strings.Join(kept, sep)
+}
+
+// ddmin is a simplified delta debugging: it removes ever smaller chunks of
+// items while fails holds of what is left
+func ddmin(items []string, fails func([]string) bool) []string {
+	n := 2
+	for len(items) >= 2 {
+		chunk := (len(items) + n - 1) / n
+		reduced := false
+		for start := 0; start < len(items); start += chunk {
+			rest := slices.Concat(items[:start], items[min(start+chunk, len(items)):])
+			if fails(rest) {
+				items, reduced = rest, true
+				n = max(n-1, 2)
+				break
+			}
+		}
+		if !reduced {
+			if n >= len(items) {
+				break
+			}
+			n = min(n*2, len(items))
+		}
+	}
+	return items
+}
+
+var intRegexp = regexp.MustCompile(`-?\d+`)
+
+// shrinkInts replaces each integer in input with 0, or else halves it
+// while fails still holds
+func shrinkInts(input string, fails func(string) bool) string {
+	for k := 0; ; k++ {
+		matches := intRegexp.FindAllStringIndex(input, -1)
+		if k >= len(matches) {
+			return input
+		}
+		start, end := matches[k][0], matches[k][1]
+		n, err := strconv.Atoi(input[start:end])
+		if err != nil {
+			continue // too big to shrink
+		}
+		with := func(n int) string { return input[:start] + strconv.Itoa(n) + input[end:] }
+		if n != 0 && fails(with(0)) {
+			input = with(0)
+			continue
+		}
+		for n/2 != n && fails(with(n/2)) {
+			input, n = with(n/2), n/2
+			end = start + len(strconv.Itoa(n))
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Package checked is int arithmetic that reports overflow rather than
+// wrapping around, for solvers whose numbers can outgrow an int.
+//
+// Each operation returns its result and whether it fit; the result of one
+// that didn't is meaningless.
+package checked
+
+import "math"
+
+// Add returns a+b.
+func Add(a, b int) (int, bool) {
+	c := a + b
+	return c, (c > a) == (b > 0)
+}
+
+// Sub returns a-b.
+func Sub(a, b int) (int, bool) {
+	c := a - b
+	return c, (c < a) == (b > 0)
+}
+
+// Mul returns a*b.
+func Mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return c, false
+	}
+	return c, c/b == a
+}
+
+// Shl returns a<<n, for a >= 0.
+func Shl(a int, n uint) (int, bool) {
+	if a == 0 {
+		return 0, true
+	}
+	if n >= 63 || a > math.MaxInt>>n {
+		return 0, false
+	}
+	return a << n, true
+}
+
+// Calc chains operations, remembering if any overflowed, so that a formula
+// can be written out whole and checked once:
+//
+//	var calc checked.Calc
+//	det := calc.Sub(calc.Mul(ax, by), calc.Mul(ay, bx))
+//	if calc.Overflow {
+//		...
+//	}
+type Calc struct {
+	Overflow bool // whether any operation so far overflowed
+}
+
+// Add returns a+b.
+func (c *Calc) Add(a, b int) int { return c.note(Add(a, b)) }
+
+// Sub returns a-b.
+func (c *Calc) Sub(a, b int) int { return c.note(Sub(a, b)) }
+
+// Mul returns a*b.
+func (c *Calc) Mul(a, b int) int { return c.note(Mul(a, b)) }
+
+// note records whether an operation's result fit
+func (c *Calc) note(r int, ok bool) int {
+	c.Overflow = c.Overflow || !ok
+	return r
+}
@@ -0,0 +1,56 @@
+package checked
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestChecked(t *testing.T) {
+	values := []int{0, 1, -1, 2, -2, 7, 1 << 31, -(1 << 31), 3037000499, 3037000500,
+		math.MaxInt / 2, math.MaxInt/2 + 1, math.MaxInt - 1, math.MaxInt, math.MinInt + 1, math.MinInt}
+	fits := func(z *big.Int) bool { return z.IsInt64() }
+	for _, a := range values {
+		for _, b := range values {
+			A, B := big.NewInt(int64(a)), big.NewInt(int64(b))
+			ops := []struct {
+				name string
+				c    int
+				ok   bool
+				want *big.Int
+			}{
+				{"+", 0, false, new(big.Int).Add(A, B)},
+				{"-", 0, false, new(big.Int).Sub(A, B)},
+				{"*", 0, false, new(big.Int).Mul(A, B)},
+			}
+			ops[0].c, ops[0].ok = Add(a, b)
+			ops[1].c, ops[1].ok = Sub(a, b)
+			ops[2].c, ops[2].ok = Mul(a, b)
+			for _, op := range ops {
+				if op.ok != fits(op.want) || (op.ok && int64(op.c) != op.want.Int64()) {
+					t.Errorf("%d %s %d = %d, %v; want %v", a, op.name, b, op.c, op.ok, op.want)
+				}
+			}
+		}
+	}
+	for _, a := range []int{0, 1, 3, math.MaxInt >> 3, math.MaxInt>>3 + 1} {
+		for n := uint(0); n < 70; n += 3 {
+			want := new(big.Int).Lsh(big.NewInt(int64(a)), n)
+			if c, ok := Shl(a, n); ok != fits(want) || (ok && int64(c) != want.Int64()) {
+				t.Errorf("%d << %d = %d, %v; want %v", a, n, c, ok, want)
+			}
+		}
+	}
+}
+
+func TestCalc(t *testing.T) {
+	var c Calc
+	if got := c.Sub(c.Mul(3, 4), c.Add(2, 5)); got != 5 || c.Overflow {
+		t.Errorf("3*4 - (2+5) = %d, %v; want 5 without overflow", got, c.Overflow)
+	}
+	c.Add(c.Mul(math.MaxInt/2, 3), 1)
+	c.Sub(1, 1)
+	if !c.Overflow {
+		t.Error("MaxInt/2 * 3 should overflow, and stay overflowed")
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/store"
+)
+
+// openStore opens the default answer store
+func openStore() (*store.Store, error) {
+	dir, err := store.DefaultDir()
+	if err != nil {
+		return nil, err
+	}
+	return store.Open(dir)
+}
+
+// loadInput reads a day's input file, falling back to the store's cached
+// copy of the real input when N/N.txt is missing.  Inputs read from files
+// are cached in the store.
+func loadInput(st *store.Store, day int, explicit string, test bool) (path, input string, err error) {
+	path = inputPath(day, explicit, test)
+	input, err = readInput(path)
+	if st == nil || explicit != "" || test {
+		return path, input, err
+	}
+	if errors.Is(err, fs.ErrNotExist) {
+		input, err := st.Input(context.Background(), day, nil)
+		return st.InputPath(day), input, err
+	}
+	if err == nil {
+		err = st.SaveInput(day, input)
+	}
+	return path, input, err
+}
+
+// recordAnswer records a result in the store, warning on stderr if the
+// answer is known wrong or has changed since the last run on this input
+func recordAnswer(st *store.Store, r aoc.Result, input string) error {
+	answer := fmt.Sprint(r.Answer)
+	before, err := st.Record(r.Day, r.Part, input, answer)
+	if err != nil {
+		return err
+	}
+	hash := store.HashInput(input)
+	if before.Verdict(hash, answer) == store.Wrong {
+		msg := fmt.Sprintf("%d.%d: warning: %s is known wrong", r.Day, r.Part, answer)
+		if correct, ok := before.Correct(hash); ok {
+			msg += ", the confirmed answer is " + correct
+		}
+		fmt.Fprintln(os.Stderr, msg)
+	}
+	if last, ok := before.Last(hash); ok && last.Answer != answer {
+		fmt.Fprintf(os.Stderr, "%d.%d: warning: answer changed from %s (%s, %s)\n",
+			r.Day, r.Part, last.Answer, last.Version, last.Time.Local().Format(time.DateTime))
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func answersCmd(args []string) error {
+	fs := newFlagSet("answers", "answers <days> [flags]")
+	partFlag := fs.Int("part", 0, "part to show, 1 or 2 (default both)")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return badUsage(fs, "answers expects one day spec, got %d", len(positional))
+	}
+	days, err := aoc.Select(positional[0])
+	if err != nil {
+		return err
+	}
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "day\tpart\ttime\tanswer\tverdict\tinput\tversion")
+	for _, d := range days {
+		for part := 1; part <= 2; part++ {
+			if *partFlag != 0 && *partFlag != part {
+				continue
+			}
+			p, err := st.Part(d.Day, part)
+			if err != nil {
+				return err
+			}
+			for _, r := range p.History {
+				verdict := string(p.Verdict(r.InputHash, r.Answer))
+				if verdict == "" {
+					verdict = "-"
+				}
+				fmt.Fprintf(tw, "%d\t%d\t%s\t%s\t%s\t%s\t%s\n", d.Day, part,
+					r.Time.Local().Format(time.DateTime), r.Answer, verdict, r.InputHash, r.Version)
+			}
+		}
+	}
+	return tw.Flush()
+}
+
+func markCmd(args []string) error {
+	fs := newFlagSet("mark", "mark <day> <part> <answer> correct|wrong [flags]")
+	inputFlag := fs.String("input", "", "puzzle input file the answer is for (default N/N.txt, or the cached input)")
+	testFlag := fs.Bool("test", false, "the answer is for the example input N/N.test.txt")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 4 {
+		return badUsage(fs, "mark expects 4 arguments, got %d", len(positional))
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return fmt.Errorf("bad day %q", positional[0])
+	}
+	part, err := strconv.Atoi(positional[1])
+	if err != nil || part < 1 || part > 2 {
+		return fmt.Errorf("bad part %q", positional[1])
+	}
+	verdict, err := store.ParseVerdict(positional[3])
+	if err != nil {
+		return err
+	}
+
+	st, err := openStore()
+	if err != nil {
+		return err
+	}
+	_, input, err := loadInput(st, day, *inputFlag, *testFlag)
+	if err != nil {
+		return err
+	}
+	return st.Mark(day, part, input, positional[2], verdict)
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
+)
+
+// benchResult is one part's benchmark, as saved in a baseline file
+type benchResult struct {
+	Day         int   `json:"day"`
+	Part        int   `json:"part"`
+	N           int   `json:"n"`
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+type benchReport struct {
+	Results []benchResult `json:"results"`
+}
+
+func (r *benchReport) find(day, part int) *benchResult {
+	for i := range r.Results {
+		if r.Results[i].Day == day && r.Results[i].Part == part {
+			return &r.Results[i]
+		}
+	}
+	return nil
+}
+
+func readBaseline(path string) (*benchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var report benchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return &report, nil
+}
+
+// benchPart benchmarks one part of d, with ctx's params, failing on the
+// first solver error
+func benchPart(ctx context.Context, d *aoc.Day, part int, input string) (testing.BenchmarkResult, error) {
+	var solveErr error
+	result := testing.Benchmark(func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := d.Solve(ctx, part, input); err != nil {
+				solveErr = err
+				b.SkipNow()
+			}
+		}
+	})
+	return result, solveErr
+}
+
+// percentChange formats the change from base to now, like "-12.5%"
+func percentChange(now, base int64) string {
+	if base == 0 {
+		if now == 0 {
+			return "+0.0%"
+		}
+		return "new"
+	}
+	return fmt.Sprintf("%+.1f%%", 100*float64(now-base)/float64(base))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func benchCmd(args []string) error {
+	fs := newFlagSet("bench", "bench <days> [flags]")
+	inputFlag := fs.String("input", "", "puzzle input file (default N/N.txt)")
+	testFlag := fs.Bool("test", false, "use the example input N/N.test.txt")
+	partFlag := fs.Int("part", 0, "part to benchmark, 1 or 2 (default both)")
+	benchtimeFlag := fs.String("benchtime", "1s", "run each part for duration `d`, or N times with Nx")
+	baselineFlag := fs.String("baseline", "", "compare against a baseline JSON `file`")
+	saveFlag := fs.String("save", "", "save the results as a baseline JSON `file`")
+	paramFlags := addParamFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return badUsage(fs, "bench expects one day spec, got %d", len(positional))
+	}
+	if *partFlag < 0 || *partFlag > 2 {
+		return fmt.Errorf("bad --part %d", *partFlag)
+	}
+
+	days, err := aoc.Select(positional[0])
+	if err != nil {
+		return err
+	}
+	if *inputFlag != "" && len(days) > 1 {
+		return fmt.Errorf("--input requires a single day")
+	}
+	params, err := paramFlags.read(days)
+	if err != nil {
+		return err
+	}
+
+	var baseline *benchReport
+	if *baselineFlag != "" {
+		if baseline, err = readBaseline(*baselineFlag); err != nil {
+			return err
+		}
+	}
+
+	// testing.Benchmark takes its benchtime from the testing flags
+	testing.Init()
+	if err := flag.Set("test.benchtime", *benchtimeFlag); err != nil {
+		return fmt.Errorf("bad --benchtime %q: %w", *benchtimeFlag, err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', tabwriter.AlignRight)
+	header := "day\tpart\tns/op\tallocs/op\tB/op\tday total\t"
+	if baseline != nil {
+		header += "Δ ns/op\tΔ allocs/op\t"
+	}
+	fmt.Fprintln(tw, header)
+
+	var report benchReport
+	failed := false
+	start := time.Now()
+	for _, d := range days {
+		path := inputPath(d.Day, *inputFlag, *testFlag)
+		input, err := readInput(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading file: %s\n", err.Error())
+			failed = true
+			continue
+		}
+
+		var dayTotal time.Duration
+		for part := 1; part <= 2; part++ {
+			if (*partFlag != 0 && *partFlag != part) || !d.HasPart(part) {
+				continue
+			}
+			result, err := benchPart(aoc.WithParams(context.Background(), params[d.Day]), d, part, input)
+			if err != nil {
+				tw.Flush()
+				fmt.Fprintf(os.Stderr, "%d.%d: error: %s\n", d.Day, part, parse.Named(err, inputName(path)).Error())
+				failed = true
+				continue
+			}
+
+			r := benchResult{
+				Day:         d.Day,
+				Part:        part,
+				N:           result.N,
+				NsPerOp:     result.NsPerOp(),
+				AllocsPerOp: result.AllocsPerOp(),
+				BytesPerOp:  result.AllocedBytesPerOp(),
+			}
+			report.Results = append(report.Results, r)
+			dayTotal += time.Duration(r.NsPerOp)
+
+			line := fmt.Sprintf("%d\t%d\t%d\t%d\t%d\t\t", r.Day, r.Part, r.NsPerOp, r.AllocsPerOp, r.BytesPerOp)
+			if baseline != nil {
+				if base := baseline.find(r.Day, r.Part); base != nil {
+					line += percentChange(r.NsPerOp, base.NsPerOp) + "\t" +
+						percentChange(r.AllocsPerOp, base.AllocsPerOp) + "\t"
+				} else {
+					line += "-\t-\t"
+				}
+			}
+			fmt.Fprintln(tw, line)
+		}
+		if dayTotal > 0 {
+			line := fmt.Sprintf("%d\t\t\t\t\t%s\t", d.Day, dayTotal)
+			if baseline != nil {
+				line += "\t\t"
+			}
+			fmt.Fprintln(tw, line)
+		}
+	}
+	tw.Flush()
+	fmt.Printf("\nbenchmarked in %s\n", time.Since(start).Round(time.Millisecond))
+
+	if *saveFlag != "" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*saveFlag, append(data, '\n'), 0644); err != nil {
+			return err
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	return nil
+}
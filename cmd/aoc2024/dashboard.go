@@ -0,0 +1,215 @@
+package main
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strconv"
+	"sync"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/export"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+// web is the dashboard, a static page drawing grids on a canvas, with no
+// dependencies beyond the browser
+//
+//go:embed web
+var web embed.FS
+
+// maxSnapshots is how many snapshots a solve sends the dashboard
+const maxSnapshots = 16
+
+// maxReplayFrames is the most frames a replay sends the dashboard
+const maxReplayFrames = 2000
+
+// gridRecord is the JSON form of a snapshot, as rows of glyphs
+type gridRecord struct {
+	Name string   `json:"name"`
+	Rows []string `json:"rows"`
+}
+
+// gridsRecord is a part's result with the snapshots solving it took
+type gridsRecord struct {
+	resultRecord
+	Grids  []gridRecord      `json:"grids"`
+	Colors map[string]string `json:"colors"` // by glyph, as "#rrggbb"
+}
+
+// frameRecord is the JSON form of a replay.Frame
+type frameRecord struct {
+	Step    int      `json:"step"`
+	Caption string   `json:"caption,omitempty"`
+	Rows    []string `json:"rows"`
+}
+
+// replayRecord is a recorded simulation
+type replayRecord struct {
+	Day    int               `json:"day"`
+	Steps  int               `json:"steps"`
+	Frames []frameRecord     `json:"frames"`
+	Colors map[string]string `json:"colors"`
+}
+
+func gridRows(g *grid.Grid[byte]) []string {
+	rows := make([]string, g.Height())
+	for y := range rows {
+		rows[y] = string(g.Row(y))
+	}
+	return rows
+}
+
+// gridColors is the export.DefaultPalette colour of each glyph in grids,
+// plus the background under " "
+func gridColors(grids ...*grid.Grid[byte]) map[string]string {
+	hex := func(glyph byte) string {
+		c := export.DefaultPalette.Color(glyph)
+		return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+	}
+	colors := map[string]string{" ": hex(' ')}
+	for _, g := range grids {
+		for _, c := range g.All() {
+			if _, ok := colors[string(c)]; !ok {
+				colors[string(c)] = hex(c)
+			}
+		}
+	}
+	return colors
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// dashboard serves the embedded web page
+func (s *server) dashboard() http.Handler {
+	sub, err := fs.Sub(web, "web")
+	if err != nil {
+		panic(err) // the embed is broken
+	}
+	return http.FileServerFS(sub)
+}
+
+// example serves a day's example input, N/N.test.txt
+func (s *server) example(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.dayRequest(w, r)
+	if !ok {
+		return
+	}
+	data, err := fs.ReadFile(s.examples, inputPath(d.Day, "", true))
+	if err != nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("day %d has no example", d.Day))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Write(data)
+}
+
+// solveGrids solves a part like solve, also answering with its snapshots
+func (s *server) solveGrids(w http.ResponseWriter, r *http.Request) {
+	d, part, params, input, ok := s.solveRequest(w, r)
+	if !ok {
+		return
+	}
+	var mu sync.Mutex // solvers may snapshot from several goroutines
+	var snapshots []*grid.Grid[byte]
+	var grids []gridRecord
+	ctx := aoc.WithSnapshots(aoc.WithParams(r.Context(), params), func(name string, g *grid.Grid[byte]) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(grids) < maxSnapshots {
+			snapshots = append(snapshots, g)
+			grids = append(grids, gridRecord{Name: name, Rows: gridRows(g)})
+		}
+	})
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+	result := s.runPart(ctx, d, input, part)
+	if errors.Is(result.Err, context.Canceled) {
+		return
+	}
+	status := errorStatus(result.Err)
+	aoc.Log.Info("solved", "day", d.Day, "part", part, "bytes", len(input), "status", status, "elapsed", result.Elapsed, "grids", len(grids))
+
+	mu.Lock() // a solver that timed out may still be snapshotting
+	rec := gridsRecord{resultRecord: newResultRecord(result), Grids: slices.Clip(grids), Colors: gridColors(snapshots...)}
+	mu.Unlock()
+	if rec.Grids == nil {
+		rec.Grids = []gridRecord{}
+	}
+	writeJSON(w, status, rec)
+}
+
+// replay records a day's simulation of the puzzle in the body, keeping at
+// most ?frames=N frames, with puzzle parameters in the rest of the query
+func (s *server) replay(w http.ResponseWriter, r *http.Request) {
+	d, ok := s.dayRequest(w, r)
+	if !ok {
+		return
+	}
+	if d.Replay == nil {
+		writeError(w, http.StatusNotFound, fmt.Errorf("day %d has no simulation to replay", d.Day))
+		return
+	}
+	maxFrames := replay.DefaultMaxFrames
+	if v := r.URL.Query().Get("frames"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > maxReplayFrames {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("bad frames %q, expected 1 to %d", v, maxReplayFrames))
+			return
+		}
+		maxFrames = n
+	}
+	params := make(aoc.Params)
+	for name, values := range r.URL.Query() {
+		if name != "frames" {
+			params[name] = values[len(values)-1]
+		}
+	}
+	if _, err := d.NewSolver(params); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	input, ok := s.readPuzzle(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(aoc.WithParams(r.Context(), params), s.timeout)
+	defer cancel()
+	rec := replay.NewRecorder(maxFrames)
+	var replayErr error // only read if the replay finished
+	err := s.run(ctx, func() {
+		defer func() {
+			if v := recover(); v != nil {
+				replayErr = &aoc.PanicError{Value: v, Stack: debug.Stack()}
+			}
+		}()
+		replayErr = d.Replay(ctx, input, rec)
+	})
+	if err == nil {
+		err = replayErr
+	}
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+
+	out := replayRecord{Day: d.Day, Steps: rec.Steps(), Frames: make([]frameRecord, 0, len(rec.Frames()))}
+	var grids []*grid.Grid[byte]
+	for _, f := range rec.Frames() {
+		out.Frames = append(out.Frames, frameRecord{Step: f.Step, Caption: f.Caption, Rows: gridRows(f.Grid)})
+		grids = append(grids, f.Grid)
+	}
+	out.Colors = gridColors(grids...)
+	aoc.Log.Info("replayed", "day", d.Day, "bytes", len(input), "steps", out.Steps, "frames", len(out.Frames))
+	writeJSON(w, http.StatusOK, out)
+}
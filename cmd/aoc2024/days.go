@@ -0,0 +1,21 @@
+package main
+
+// Every day registers itself with the aoc registry when imported.
+import (
+	_ "github.com/neomantra/aoc2024/1"
+	_ "github.com/neomantra/aoc2024/10"
+	_ "github.com/neomantra/aoc2024/11"
+	_ "github.com/neomantra/aoc2024/12"
+	_ "github.com/neomantra/aoc2024/13"
+	_ "github.com/neomantra/aoc2024/14"
+	_ "github.com/neomantra/aoc2024/15"
+	_ "github.com/neomantra/aoc2024/17"
+	_ "github.com/neomantra/aoc2024/2"
+	_ "github.com/neomantra/aoc2024/3"
+	_ "github.com/neomantra/aoc2024/4"
+	_ "github.com/neomantra/aoc2024/5"
+	_ "github.com/neomantra/aoc2024/6"
+	_ "github.com/neomantra/aoc2024/7"
+	_ "github.com/neomantra/aoc2024/8"
+	_ "github.com/neomantra/aoc2024/9"
+)
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/export"
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// imageWriters are the --export-format formats
+var imageWriters = map[string]func(io.Writer, *grid.Grid[byte], export.Palette, int) error{
+	"png": export.PNG,
+	"svg": export.SVG,
+}
+
+// snapshotExporter writes solver snapshots as images into a directory.
+// It is safe for concurrent solvers.
+type snapshotExporter struct {
+	dir     string
+	formats []string
+	scale   int
+
+	mu  sync.Mutex
+	err error // the first failure
+}
+
+// newSnapshotExporter checks formats, a comma separated list, and makes dir
+func newSnapshotExporter(dir, formats string, scale int) (*snapshotExporter, error) {
+	e := &snapshotExporter{dir: dir, scale: scale}
+	for _, f := range strings.Split(formats, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		if _, ok := imageWriters[f]; !ok {
+			return nil, fmt.Errorf("bad --export-format %q, expected png, svg or both", f)
+		}
+		e.formats = append(e.formats, f)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// snapshotName is the file name, without extension, of a snapshot:
+// e.g. "6.1.path", or "6.1.path.6.test" when labelled with its input
+func snapshotName(prefix, name, input string) string {
+	base := prefix + "." + name
+	if input != "" {
+		base += "." + inputLabel(input)
+	}
+	return base
+}
+
+// For returns a SnapshotFunc writing files named by snapshotName
+func (e *snapshotExporter) For(prefix, input string) aoc.SnapshotFunc {
+	return func(name string, g *grid.Grid[byte]) {
+		for _, format := range e.formats {
+			path := filepath.Join(e.dir, snapshotName(prefix, name, input)+"."+format)
+			if err := writeImage(path, g, imageWriters[format], e.scale); err != nil {
+				e.mu.Lock()
+				if e.err == nil {
+					e.err = err
+				}
+				e.mu.Unlock()
+			}
+		}
+	}
+}
+
+// Err returns the first failure to write a snapshot
+func (e *snapshotExporter) Err() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.err
+}
+
+func writeImage(path string, g *grid.Grid[byte], write func(io.Writer, *grid.Grid[byte], export.Palette, int) error, scale int) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := write(f, g, export.DefaultPalette, scale); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+func TestSnapshotExporter(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "out")
+	e, err := newSnapshotExporter(dir, "png, SVG", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := grid.New[byte](3, 2)
+	e.For("6.1", "")("path", g)
+	e.For("6.1", "6/6.test.txt")("path", g)
+	if err := e.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, entry := range entries {
+		got = append(got, entry.Name())
+	}
+	want := []string{"6.1.path.6.test.png", "6.1.path.6.test.svg", "6.1.path.png", "6.1.path.svg"}
+	if !slices.Equal(got, want) {
+		t.Errorf("wrote %v, want %v", got, want)
+	}
+
+	if _, err := newSnapshotExporter(dir, "png,jpeg", 2); err == nil {
+		t.Error("jpeg format accepted")
+	}
+}
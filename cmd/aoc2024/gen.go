@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+func genCmd(args []string) error {
+	fs := newFlagSet("gen", "gen <day> [flags]\n\n"+
+		"Writes a random valid input to stdout, e.g. aoc2024 gen 6 | aoc2024 run 6 -")
+	seedFlag := fs.Uint64("seed", 0, "random `seed` (default from the clock, and reported on stderr)")
+	sizeFlag := fs.Int("size", 20, "input `size`, roughly lines or grid width")
+	countFlag := fs.Int("count", 1, "number of inputs, with consecutive seeds; more than one needs --out")
+	outFlag := fs.String("out", "", "write inputs to `dir` as N.gen.SEED.txt instead of stdout")
+	verifyFlag := fs.Bool("verify", false, "instead of writing inputs, cross-check the day's fast and naive implementations on them")
+	timeoutFlag := fs.Duration("timeout", 0, "with --verify, give up on an input after `duration` (default no limit)")
+	paramFlags := addParamFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return badUsage(fs, "gen expects one day, got %d", len(positional))
+	}
+	if *sizeFlag < 1 {
+		return fmt.Errorf("bad --size %d", *sizeFlag)
+	}
+	if *countFlag > 1 && *outFlag == "" && !*verifyFlag {
+		return badUsage(fs, "--count needs --out")
+	}
+
+	days, err := aoc.Select(positional[0])
+	if err != nil {
+		return err
+	}
+	if len(days) != 1 {
+		return badUsage(fs, "gen expects one day")
+	}
+	d := days[0]
+	if d.Generate == nil {
+		return fmt.Errorf("day %d has no generator", d.Day)
+	}
+	params, err := paramFlags.read(days)
+	if err != nil {
+		return err
+	}
+	ctx := aoc.WithParams(context.Background(), params[d.Day])
+
+	seed := *seedFlag
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+		fmt.Fprintf(os.Stderr, "seed %d\n", seed)
+	}
+	if *verifyFlag {
+		if !d.CanVerify() {
+			return fmt.Errorf("day %d has no naive implementation to verify against", d.Day)
+		}
+		failed := false
+		for i := 0; i < *countFlag; i++ {
+			input := d.Generate(ctx, aoc.NewRand(seed), *sizeFlag)
+			if !verifyInput(d, fmt.Sprintf("seed %d", seed), input, *timeoutFlag, params[d.Day]) {
+				failed = true
+			}
+			seed++
+		}
+		if failed {
+			os.Exit(1)
+		}
+		return nil
+	}
+	if *outFlag == "" {
+		_, err := fmt.Print(d.Generate(ctx, aoc.NewRand(seed), *sizeFlag))
+		return err
+	}
+
+	if err := os.MkdirAll(*outFlag, 0755); err != nil {
+		return err
+	}
+	for i := 0; i < *countFlag; i++ {
+		input := d.Generate(ctx, aoc.NewRand(seed), *sizeFlag)
+		path := filepath.Join(*outFlag, fmt.Sprintf("%d.gen.%d.txt", d.Day, seed))
+		if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+			return err
+		}
+		seed++
+	}
+	return nil
+}
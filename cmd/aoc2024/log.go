@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+// logFlags are the verbosity flags of the commands that solve
+type logFlags struct {
+	verbose, debug, quiet *bool
+	format                *string
+}
+
+func addLogFlags(fs *flag.FlagSet) *logFlags {
+	return &logFlags{
+		verbose: fs.Bool("v", false, "log what the solvers find, to stderr"),
+		debug:   fs.Bool("vv", false, "also log their boards and intermediate state"),
+		quiet:   fs.Bool("q", false, "log only errors"),
+		format:  fs.String("log-format", "text", "log `format`: text, or json for one record per line"),
+	}
+}
+
+// setup points aoc.Log at w, at the level the flags ask for
+func (lf *logFlags) setup(w io.Writer) error {
+	level := slog.LevelWarn
+	switch {
+	case *lf.quiet:
+		level = slog.LevelError
+	case *lf.debug:
+		level = slog.LevelDebug
+	case *lf.verbose:
+		level = slog.LevelInfo
+	}
+	opts := &slog.HandlerOptions{Level: level}
+	switch *lf.format {
+	case "text":
+		aoc.Log = slog.New(newBoardHandler(w, opts))
+	case "json":
+		aoc.Log = slog.New(slog.NewJSONHandler(w, opts))
+	default:
+		return fmt.Errorf("bad --log-format %q, expected text or json", *lf.format)
+	}
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// boardHandler writes records as slog's text format does, except that
+// multi-line values, like boards, follow the record's line as they would
+// print, so they can be read
+type boardHandler struct {
+	text slog.Handler
+	buf  *bytes.Buffer // the text handler's output
+	mu   *sync.Mutex   // guards buf and w
+	w    io.Writer
+}
+
+func newBoardHandler(w io.Writer, opts *slog.HandlerOptions) *boardHandler {
+	buf := new(bytes.Buffer)
+	return &boardHandler{text: slog.NewTextHandler(buf, opts), buf: buf, mu: new(sync.Mutex), w: w}
+}
+
+func (h *boardHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.text.Enabled(ctx, level)
+}
+
+func (h *boardHandler) Handle(ctx context.Context, r slog.Record) error {
+	inline := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	var boards []slog.Attr
+	r.Attrs(func(a slog.Attr) bool {
+		a.Value = a.Value.Resolve()
+		if a.Value.Kind() == slog.KindString && strings.Contains(a.Value.String(), "\n") {
+			boards = append(boards, a)
+		} else {
+			inline.AddAttrs(a)
+		}
+		return true
+	})
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.buf.Reset()
+	if err := h.text.Handle(ctx, inline); err != nil {
+		return err
+	}
+	for _, a := range boards {
+		fmt.Fprintf(h.buf, "%s:\n%s", a.Key, a.Value.String())
+		if !strings.HasSuffix(a.Value.String(), "\n") {
+			h.buf.WriteByte('\n')
+		}
+	}
+	_, err := h.w.Write(h.buf.Bytes())
+	return err
+}
+
+func (h *boardHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &boardHandler{text: h.text.WithAttrs(attrs), buf: h.buf, mu: h.mu, w: h.w}
+}
+
+func (h *boardHandler) WithGroup(name string) slog.Handler {
+	return &boardHandler{text: h.text.WithGroup(name), buf: h.buf, mu: h.mu, w: h.w}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestBoardHandler(t *testing.T) {
+	var buf bytes.Buffer
+	opts := &slog.HandlerOptions{
+		Level: slog.LevelDebug,
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}
+	log := slog.New(newBoardHandler(&buf, opts)).With("day", 15)
+	log.Debug("warehouse", "map", "#.@\n#O.", "moves", 2)
+	log.Info("done", "score", 7)
+
+	want := "level=DEBUG msg=warehouse day=15 moves=2\nmap:\n#.@\n#O.\n" +
+		"level=INFO msg=done day=15 score=7\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+
+	buf.Reset()
+	slog.New(newBoardHandler(&buf, nil)).Debug("hidden")
+	if strings.Contains(buf.String(), "hidden") {
+		t.Error("logged below the handler's level")
+	}
+}
@@ -0,0 +1,500 @@
+// aoc2024 runs the Advent of Code 2024 solvers
+//
+//	aoc2024 list
+//	aoc2024 run 6 --input 6/6.txt --part 2
+//	aoc2024 run 1-17 --test
+//	aoc2024 run 6 6/6.test.txt 6/6.txt
+//	aoc2024 gen 6 --seed 42 | aoc2024 run 6 -
+//	aoc2024 run 1-17 --format ndjson
+//	aoc2024 bench 1-17 --baseline bench.json
+//	aoc2024 run 6 --store && aoc2024 mark 6 1 41 correct
+//	aoc2024 interactive 17
+//	aoc2024 replay 6 --test
+//	aoc2024 new 16
+//	aoc2024 watch 6
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/store"
+)
+
+const usage = `usage: aoc2024 <command> [arguments]
+
+commands:
+  list [flags]              list registered days, and their puzzle parameters
+  run <days> [input...] [flags]
+                            solve days, e.g. "6", "1-17" or "1,3,5-7";
+                            inputs are files, or "-" for stdin
+  bench <days> [flags]      benchmark days, optionally against a baseline
+  gen <day> [flags]         generate a random valid input
+  answers <days> [flags]    show the answer history from the store
+  mark <day> <part> <answer> correct|wrong
+                            record whether a submitted answer was right
+  interactive <day> [flags] run a day's interactive mode
+  replay <day> [input] [flags]
+                            play back a day's simulation in the terminal
+  serve [flags]             serve the solvers over HTTP
+  new <day> [flags]         start a new day from a skeleton, and register it
+  watch <day> [input...] [flags]
+                            solve a day again whenever its inputs change
+
+Run "aoc2024 <command> --help" for a command's flags.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch cmd, args := os.Args[1], os.Args[2:]; cmd {
+	case "list":
+		err = listCmd(args)
+	case "run":
+		err = runCmd(args)
+	case "bench":
+		err = benchCmd(args)
+	case "gen":
+		err = genCmd(args)
+	case "answers":
+		err = answersCmd(args)
+	case "mark":
+		err = markCmd(args)
+	case "interactive":
+		err = interactiveCmd(args)
+	case "replay":
+		err = replayCmd(args)
+	case "serve":
+		err = serveCmd(args)
+	case "new":
+		err = newCmd(args)
+	case "watch":
+		err = watchCmd(args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+	var usageErr *usageError
+	if errors.As(err, &usageErr) {
+		fmt.Fprintf(os.Stderr, "Error: %s\n\n", usageErr.msg)
+		usageErr.fs.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
+
+// newFlagSet returns a command's flag set, whose usage shows synopsis
+func newFlagSet(name, synopsis string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: aoc2024 %s\n", synopsis)
+		if hasFlags(fs) {
+			fmt.Fprintf(fs.Output(), "\nflags:\n")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+func hasFlags(fs *flag.FlagSet) bool {
+	has := false
+	fs.VisitAll(func(*flag.Flag) { has = true })
+	return has
+}
+
+// usageError is a mistake in a command's arguments; main shows the usage
+type usageError struct {
+	fs  *flag.FlagSet
+	msg string
+}
+
+func (e *usageError) Error() string { return e.msg }
+
+func badUsage(fs *flag.FlagSet, format string, args ...any) error {
+	return &usageError{fs: fs, msg: fmt.Sprintf(format, args...)}
+}
+
+// parseArgs parses flags interspersed with positional arguments,
+// returning the positional ones.
+func parseArgs(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// inputPath returns the puzzle file for day: explicit, or N/N.txt (N/N.test.txt)
+func inputPath(day int, explicit string, test bool) string {
+	if explicit != "" {
+		return explicit
+	}
+	if test {
+		return fmt.Sprintf("%d/%d.test.txt", day, day)
+	}
+	return fmt.Sprintf("%d/%d.txt", day, day)
+}
+
+// stdinPath is the input path meaning standard input
+const stdinPath = "-"
+
+var stdinRead = false
+
+// readInput reads a puzzle file, or stdin for "-", without its trailing newlines
+func readInput(path string) (string, error) {
+	var data []byte
+	var err error
+	if path == stdinPath {
+		if stdinRead {
+			return "", errors.New("stdin can only be read once")
+		}
+		stdinRead = true
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// inputName is how errors and results name an input
+func inputName(path string) string {
+	if path == stdinPath {
+		return "stdin"
+	}
+	return path
+}
+
+// inputLabel names an input in file names: its base name, less extension
+func inputLabel(path string) string {
+	base := filepath.Base(inputName(path))
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// stringsFlag is a flag that may be repeated
+type stringsFlag []string
+
+func (f *stringsFlag) String() string { return strings.Join(*f, ",") }
+
+func (f *stringsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// dayContext limits solving a day to timeout, if it is set
+func dayContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+func listCmd(args []string) error {
+	fs := newFlagSet("list", "list [flags]")
+	paramsFlag := fs.Bool("params", false, "show each day's puzzle parameters, with their defaults")
+	if _, err := parseArgs(fs, args); err != nil {
+		return err
+	}
+	for _, d := range aoc.Days() {
+		parts := "1"
+		if d.HasPart(2) {
+			parts += ",2"
+		}
+		extra := ""
+		if d.Interactive != nil {
+			extra = "  (interactive)"
+		}
+		if d.Generate != nil {
+			extra += "  (gen)"
+		}
+		if d.CanVerify() {
+			extra += "  (verify)"
+		}
+		if d.Replay != nil {
+			extra += "  (replay)"
+		}
+		if d.Big {
+			extra += "  (big)"
+		}
+		params := d.ParamFlags()
+		if params != nil {
+			extra += "  (params)"
+		}
+		fmt.Printf("%2d  parts %s%s\n", d.Day, parts, extra)
+		if *paramsFlag && params != nil {
+			params.VisitAll(func(f *flag.Flag) {
+				_, usage := flag.UnquoteUsage(f)
+				fmt.Printf("      %-28s %s\n", f.Name+"="+f.DefValue, usage)
+			})
+		}
+	}
+	return nil
+}
+
+func runCmd(args []string) error {
+	fs := newFlagSet("run", "run <days> [input...] [flags]\n"+
+		"       aoc2024 run --all [flags]\n\n"+
+		"Inputs default to N/N.txt; \"-\" reads stdin.  Several inputs need a single day,\n"+
+		"and each is solved in turn.")
+	var inputFlag stringsFlag
+	fs.Var(&inputFlag, "input", "puzzle input `file`, may be repeated (default N/N.txt)")
+	testFlag := fs.Bool("test", false, "use the example input N/N.test.txt")
+	allFlag := fs.Bool("all", false, "solve every registered day")
+	partFlag := fs.Int("part", 0, "part to solve, 1 or 2 (default both)")
+	parallelFlag := fs.Int("parallel", 1, "solve up to `N` parts at once, 0 for one per CPU")
+	formatFlag := fs.String("format", "text", "output `format`: text, json or ndjson")
+	timeFlag := fs.Bool("time", false, "show how long each part and day took, in text format")
+	timeoutFlag := fs.Duration("timeout", 0, "give up on a part after `duration`, e.g. 30s (default no limit)")
+	storeFlag := fs.Bool("store", false, "record answers in the store ($AOC_STORE, default in the user cache dir)")
+	verifyFlag := fs.Bool("verify", false, "instead of solving, cross-check each day's fast and naive implementations")
+	bigFlag := fs.Bool("big", false, "compute with math/big on days that can overflow an int, see list")
+	logFlags := addLogFlags(fs)
+	exportDirFlag := fs.String("export-dir", "", "write the solvers' grids as images into `dir`, e.g. 6.1.path.png")
+	exportFormatFlag := fs.String("export-format", "png", "image `formats` for --export-dir: png, svg or png,svg")
+	exportScaleFlag := fs.Int("export-scale", 8, "image `pixels` per grid cell")
+	paramFlags := addParamFlags(fs)
+	cpuProfileFlag := fs.String("cpuprofile", "", "write a CPU profile of each part solved into `dir`, e.g. 6.2.cpu.pprof")
+	memProfileFlag := fs.String("memprofile", "", "write a heap profile as each part finishes into `dir`, e.g. 6.2.mem.pprof")
+	traceFlag := fs.String("trace", "", "write an execution trace of each part solved into `dir`, e.g. 6.2.trace.out")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if *allFlag {
+		positional = append([]string{"1-25"}, positional...)
+	}
+	if len(positional) == 0 {
+		return badUsage(fs, "run expects a day spec, or --all")
+	}
+	if *partFlag < 0 || *partFlag > 2 {
+		return fmt.Errorf("bad --part %d", *partFlag)
+	}
+	parallel := *parallelFlag
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+
+	days, err := aoc.Select(positional[0])
+	if err != nil {
+		return err
+	}
+	inputs := append(inputFlag, positional[1:]...)
+	if len(inputs) > 0 && len(days) > 1 {
+		return badUsage(fs, "input files need a single day, got %d days", len(days))
+	}
+	if len(inputs) > 0 && *testFlag {
+		return badUsage(fs, "--test and input files are exclusive")
+	}
+	if len(inputs) == 0 {
+		inputs = []string{""} // the day's default
+	}
+	params, err := paramFlags.read(days)
+	if err != nil {
+		return err
+	}
+	if err := logFlags.setup(os.Stderr); err != nil {
+		return err
+	}
+	if *verifyFlag {
+		return verifyDays(days, inputs, *testFlag, *timeoutFlag, params)
+	}
+
+	out, err := newResultWriter(*formatFlag, os.Stdout, os.Stderr, outputOptions{
+		LabelInputs: len(inputs) > 1,
+		Times:       *timeFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	prof, err := newProfiler(*cpuProfileFlag, *memProfileFlag, *traceFlag)
+	if err != nil {
+		return err
+	}
+	if prof != nil && parallel > 1 {
+		return badUsage(fs, "profiling solves one part at a time, so needs --parallel 1")
+	}
+
+	var exporter *snapshotExporter
+	if *exportDirFlag != "" {
+		if exporter, err = newSnapshotExporter(*exportDirFlag, *exportFormatFlag, *exportScaleFlag); err != nil {
+			return err
+		}
+	}
+
+	var st *store.Store
+	if *storeFlag {
+		if st, err = openStore(); err != nil {
+			return err
+		}
+	}
+
+	// read every input up front, then solve each part as its own job
+	failed := false
+	var jobs []job
+	for _, d := range days {
+		for _, explicit := range inputs {
+			path, input, err := loadInput(st, d.Day, explicit, *testFlag)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading %s: %s\n", inputName(path), err.Error())
+				failed = true
+				continue
+			}
+			for part := 1; part <= 2; part++ {
+				if (*partFlag == 0 && d.HasPart(part)) || *partFlag == part {
+					j := job{day: d, part: part, path: inputName(path), input: input, params: params[d.Day], big: *bigFlag}
+					label := ""
+					if len(inputs) > 1 {
+						label = path
+					}
+					if exporter != nil {
+						j.snapshots = exporter.For(fmt.Sprintf("%d.%d", d.Day, part), label)
+					}
+					if prof != nil {
+						j.hook = prof.Hook(label)
+					}
+					jobs = append(jobs, j)
+				}
+			}
+		}
+	}
+
+	var writeErr error
+	var dayTotal time.Duration
+	start := time.Now()
+	runJobs(jobs, parallel, *timeoutFlag, func(i int, result aoc.Result) {
+		j := jobs[i]
+		result.Input = j.path
+		if result.Err != nil {
+			result.Err = parse.Named(result.Err, result.Input)
+			failed = true
+		}
+		if err := out.Write(result); err != nil && writeErr == nil {
+			writeErr = err
+		}
+		if st != nil && result.Err == nil {
+			if err := recordAnswer(st, result, j.input); err != nil && writeErr == nil {
+				writeErr = err
+			}
+		}
+
+		dayTotal += result.Elapsed
+		if i == len(jobs)-1 || jobs[i+1].day != j.day || jobs[i+1].path != j.path {
+			if err := out.DayDone(j.day.Day, j.path, dayTotal); err != nil && writeErr == nil {
+				writeErr = err
+			}
+			dayTotal = 0
+		}
+	})
+	if writeErr != nil {
+		return writeErr
+	}
+	if exporter != nil && exporter.Err() != nil {
+		return exporter.Err()
+	}
+	if prof != nil && prof.Err() != nil {
+		return prof.Err()
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if *timeFlag && *formatFlag == "text" {
+		fmt.Printf("\nsolved %d parts in %s\n", len(jobs), time.Since(start).Round(time.Millisecond))
+	}
+	if failed {
+		os.Exit(1)
+	}
+	return nil
+}
+
+func interactiveCmd(args []string) error {
+	fs := newFlagSet("interactive", "interactive <day> [flags]")
+	inputFlag := fs.String("input", "", "puzzle input file (default N/N.txt)")
+	testFlag := fs.Bool("test", false, "use the example input N/N.test.txt")
+	exportDirFlag := fs.String("export-dir", "", "write the grids the mode shows as PNG images into `dir`")
+	timeoutFlag := fs.Duration("timeout", 0, "give up after `duration`, e.g. 10m (default no limit)")
+	paramFlags := addParamFlags(fs)
+	logFlags := addLogFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return badUsage(fs, "interactive expects one day, got %d", len(positional))
+	}
+
+	days, err := aoc.Select(positional[0])
+	if err != nil {
+		return err
+	}
+	if len(days) != 1 {
+		return fmt.Errorf("interactive expects one day")
+	}
+	d := days[0]
+	if d.Interactive == nil {
+		return fmt.Errorf("day %d has no interactive mode", d.Day)
+	}
+	params, err := paramFlags.read(days)
+	if err != nil {
+		return err
+	}
+	if err := logFlags.setup(os.Stderr); err != nil {
+		return err
+	}
+	path := inputPath(d.Day, *inputFlag, *testFlag)
+	input, err := readInput(path)
+	if err != nil {
+		return err
+	}
+	// runs until done, interrupted or timed out
+	ctx, cancel := dayContext(*timeoutFlag)
+	defer cancel()
+	ctx, stop := signal.NotifyContext(aoc.WithParams(ctx, params[d.Day]), os.Interrupt)
+	defer stop()
+	var exporter *snapshotExporter
+	if *exportDirFlag != "" {
+		if exporter, err = newSnapshotExporter(*exportDirFlag, "png", 4); err != nil {
+			return err
+		}
+		ctx = aoc.WithSnapshots(ctx, exporter.For(fmt.Sprintf("%d.interactive", d.Day), ""))
+	}
+	if err := d.Interactive(ctx, input); err != nil {
+		return parse.Named(err, inputName(path))
+	}
+	if exporter != nil {
+		return exporter.Err()
+	}
+	return nil
+}
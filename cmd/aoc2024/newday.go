@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// skeleton is the starting point of a new day, in the shape of the others
+//
+//go:embed skeleton
+var skeleton embed.FS
+
+func newCmd(args []string) error {
+	fs := newFlagSet("new", "new <day> [flags]\n\n"+
+		"Starts a new day: writes a solver skeleton N/dayN.go, its test N/dayN_test.go,\n"+
+		"an empty example N/N.test.txt and the answers file N/N.answers.txt, then\n"+
+		"registers the day in cmd/aoc2024/days.go, Taskfile.yml and .vscode/launch.json.")
+	dirFlag := fs.String("dir", ".", "the repository's root `dir`")
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return badUsage(fs, "new expects one day, got %d arguments", len(positional))
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil || day < 1 || day > 25 {
+		return badUsage(fs, "bad day %q, expected 1 to 25", positional[0])
+	}
+	return scaffoldDay(*dirFlag, day)
+}
+
+// scaffoldDay writes day's skeleton under root and registers it
+func scaffoldDay(root string, day int) error {
+	module, err := modulePath(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(root, strconv.Itoa(day))
+	if _, err := os.Stat(dir); err == nil {
+		return fmt.Errorf("%s already exists", dir)
+	}
+	if err := os.Mkdir(dir, 0755); err != nil {
+		return err
+	}
+
+	files := []struct{ template, name string }{
+		{"day.go.tmpl", fmt.Sprintf("day%d.go", day)},
+		{"day_test.go.tmpl", fmt.Sprintf("day%d_test.go", day)},
+		{"answers.txt.tmpl", fmt.Sprintf("%d.answers.txt", day)},
+		{"", fmt.Sprintf("%d.test.txt", day)}, // for the example, pasted from the puzzle
+	}
+	for _, f := range files {
+		var buf bytes.Buffer
+		if f.template != "" {
+			t, err := template.ParseFS(skeleton, "skeleton/"+f.template)
+			if err != nil {
+				return err
+			}
+			if err := t.Execute(&buf, struct{ Day int }{day}); err != nil {
+				return err
+			}
+		}
+		path := filepath.Join(dir, f.name)
+		if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("wrote %s\n", path)
+	}
+
+	registrations := []struct {
+		path     string
+		register func(data []byte, module string, day int) ([]byte, error)
+	}{
+		{filepath.Join(root, "cmd", "aoc2024", "days.go"), registerImport},
+		{filepath.Join(root, "Taskfile.yml"), registerTask},
+		{filepath.Join(root, ".vscode", "launch.json"), registerLaunch},
+	}
+	for _, r := range registrations {
+		data, err := os.ReadFile(r.path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		updated, err := r.register(data, module, day)
+		if err != nil {
+			return fmt.Errorf("%s: %w", r.path, err)
+		}
+		if bytes.Equal(updated, data) {
+			continue
+		}
+		if err := os.WriteFile(r.path, updated, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("updated %s\n", r.path)
+	}
+
+	fmt.Printf("\nnext, paste the example into %[1]s/%[2]d.test.txt and its answers into\n"+
+		"%[1]s/%[2]d.answers.txt, then solve until `go test ./%[2]d` passes\n", dir, day)
+	return nil
+}
+
+// modulePath reads the module path from a go.mod file
+func modulePath(gomod string) (string, error) {
+	data, err := os.ReadFile(gomod)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if path, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok {
+			return strings.Trim(strings.TrimSpace(path), `"`), nil
+		}
+	}
+	return "", fmt.Errorf("%s has no module line", gomod)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// registerImport adds day's blank import to days.go's import block
+func registerImport(data []byte, module string, day int) ([]byte, error) {
+	spec := fmt.Sprintf("\t_ %q", fmt.Sprintf("%s/%d", module, day))
+	lines := strings.Split(string(data), "\n")
+	start := slices.IndexFunc(lines, func(line string) bool { return strings.HasPrefix(line, "import (") })
+	if start < 0 {
+		return nil, errors.New("no import block")
+	}
+	end := start + 1
+	for end < len(lines) && lines[end] != ")" {
+		end++
+	}
+	if end == len(lines) {
+		return nil, errors.New("unterminated import block")
+	}
+	imports := lines[start+1 : end]
+	if slices.Contains(imports, spec) {
+		return data, nil
+	}
+	imports = append(slices.Clone(imports), spec)
+	slices.Sort(imports)
+	lines = slices.Concat(lines[:start+1], imports, lines[end:])
+	return format.Source([]byte(strings.Join(lines, "\n")))
+}
+
+// examplesRange is the range of days the Taskfile's examples task runs
+var examplesRange = regexp.MustCompile(`(run 1-)(\d+)( --test)`)
+
+// registerTask extends the examples task's range of days to day
+func registerTask(data []byte, module string, day int) ([]byte, error) {
+	m := examplesRange.FindSubmatchIndex(data)
+	if m == nil {
+		return nil, errors.New(`no "run 1-N --test" in the examples task`)
+	}
+	last, _ := strconv.Atoi(string(data[m[4]:m[5]]))
+	if day <= last {
+		return data, nil
+	}
+	return slices.Concat(data[:m[4]], []byte(strconv.Itoa(day)), data[m[5]:]), nil
+}
+
+// launchConfig is a debug configuration running a day's example
+const launchConfig = `        {
+            "name": "Debug %[1]d",
+            "type": "go",
+            "request": "launch",
+            "mode": "debug",
+            "program": "${workspaceFolder}/cmd/aoc2024",
+            "cwd": "${workspaceFolder}",
+            "args": [
+                "run", "%[1]d", "--test"
+            ]
+        },
+`
+
+// registerLaunch adds a configuration debugging day to launch.json.  It
+// edits the text, keeping its comments, rather than the JSON.
+func registerLaunch(data []byte, module string, day int) ([]byte, error) {
+	text := string(data)
+	if strings.Contains(text, fmt.Sprintf(`"name": "Debug %d"`, day)) {
+		return data, nil
+	}
+	_, after, ok := strings.Cut(text, `"configurations": [`)
+	if !ok {
+		return nil, errors.New(`no "configurations"`)
+	}
+	end := len(text) - len(after) + closingBracket(after)
+	if end < len(text)-len(after) {
+		return nil, errors.New(`unterminated "configurations"`)
+	}
+	// insert at the start of the closing bracket's line, after the last
+	// configuration, giving it a trailing comma if it lacks one
+	before := strings.TrimRight(text[:end], " \t")
+	indent := text[len(before):end]
+	head := strings.TrimRight(before, " \t\r\n")
+	if !strings.HasSuffix(head, ",") && !strings.HasSuffix(head, "[") {
+		before = head + ",\n"
+	}
+	if !strings.HasSuffix(before, "\n") {
+		before += "\n"
+	}
+	return []byte(before + fmt.Sprintf(launchConfig, day) + indent + text[end:]), nil
+}
+
+// closingBracket returns the index of the ']' closing a list whose '[' is
+// just before text, skipping strings and comments, or -1
+func closingBracket(text string) int {
+	depth := 0
+	inString := false
+	for i := 0; i < len(text); i++ {
+		switch c := text[i]; {
+		case inString && c == '\\':
+			i++
+		case c == '"':
+			inString = !inString
+		case inString:
+		case strings.HasPrefix(text[i:], "//"):
+			for i < len(text) && text[i] != '\n' {
+				i++
+			}
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
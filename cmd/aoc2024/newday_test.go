@@ -0,0 +1,96 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRegisterDay(t *testing.T) {
+	days := "package main\n\nimport (\n\t_ \"example.com/aoc/1\"\n\t_ \"example.com/aoc/17\"\n)\n"
+	got, err := registerImport([]byte(days), "example.com/aoc", 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "\t_ \"example.com/aoc/1\"\n\t_ \"example.com/aoc/16\"\n\t_ \"example.com/aoc/17\"\n"; !strings.Contains(string(got), want) {
+		t.Errorf("registerImport: got\n%s", got)
+	}
+	if again, _ := registerImport(got, "example.com/aoc", 16); string(again) != string(got) {
+		t.Errorf("registerImport twice: got\n%s", again)
+	}
+
+	task := "      - ./bin/aoc2024 run 1-17 --test --config test.params.yaml\n"
+	if got, _ := registerTask([]byte(task), "", 18); !strings.Contains(string(got), "run 1-18 --test") {
+		t.Errorf("registerTask 18: got %s", got)
+	}
+	if got, _ := registerTask([]byte(task), "", 16); string(got) != task {
+		t.Errorf("registerTask 16: got %s", got)
+	}
+
+	launch := `{
+    // comments stay, even with a ] in them
+    "configurations": [
+        {
+            "name": "Debug day",
+            "args": ["run", "${input:day}"]
+        }
+    ],
+    "inputs": []
+}
+`
+	got, err = registerLaunch([]byte(launch), "", 16)
+	if err != nil {
+		t.Fatal(err)
+	}
+	text := string(got)
+	if !strings.Contains(text, "// comments stay") ||
+		!strings.Contains(text, "        },\n        {\n            \"name\": \"Debug 16\"") ||
+		!strings.Contains(text, "                \"run\", \"16\", \"--test\"\n            ]\n        },\n    ],\n    \"inputs\"") {
+		t.Errorf("registerLaunch: got\n%s", text)
+	}
+	if again, _ := registerLaunch(got, "", 16); string(again) != text {
+		t.Errorf("registerLaunch twice: got\n%s", again)
+	}
+}
+
+func TestScaffoldDay(t *testing.T) {
+	root := t.TempDir()
+	write := func(path, text string) {
+		path = filepath.Join(root, path)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/aoc\n\ngo 1.23\n")
+	write("cmd/aoc2024/days.go", "package main\n\nimport (\n\t_ \"example.com/aoc/1\"\n)\n")
+
+	if err := scaffoldDay(root, 16); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"day16.go", "day16_test.go", "16.answers.txt", "16.test.txt"} {
+		if _, err := os.Stat(filepath.Join(root, "16", name)); err != nil {
+			t.Error(err)
+		}
+	}
+	solver, err := os.ReadFile(filepath.Join(root, "16", "day16.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(solver), "package day16") || !strings.Contains(string(solver), "aoc.Day{Day: 16,") {
+		t.Errorf("day16.go:\n%s", solver)
+	}
+	days, err := os.ReadFile(filepath.Join(root, "cmd", "aoc2024", "days.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(days), `_ "example.com/aoc/16"`) {
+		t.Errorf("days.go:\n%s", days)
+	}
+	if err := scaffoldDay(root, 16); err == nil {
+		t.Error("scaffolding day 16 twice: expected error")
+	}
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+// resultWriter reports run results in one of the --format styles
+type resultWriter interface {
+	Write(r aoc.Result) error
+	DayDone(day int, input string, total time.Duration) error // after a day's parts
+	Close() error
+}
+
+// outputOptions tune the text format; JSON records always carry everything
+type outputOptions struct {
+	LabelInputs bool // name each result's input, for runs over several inputs
+	Times       bool // show part and day timings
+}
+
+// newResultWriter returns a writer for format; answers go to out, and
+// text-mode errors to errOut.
+func newResultWriter(format string, out, errOut io.Writer, opts outputOptions) (resultWriter, error) {
+	switch format {
+	case "text":
+		return &textWriter{out: out, errOut: errOut, opts: opts}, nil
+	case "ndjson":
+		return &ndjsonWriter{enc: json.NewEncoder(out)}, nil
+	case "json":
+		return &jsonWriter{out: out}, nil
+	default:
+		return nil, fmt.Errorf("bad --format %q, expected text, json or ndjson", format)
+	}
+}
+
+// resultRecord is the JSON form of an aoc.Result
+type resultRecord struct {
+	Day       int        `json:"day"`
+	Part      int        `json:"part"`
+	Input     string     `json:"input,omitempty"`
+	Answer    aoc.Answer `json:"answer,omitempty"`
+	ElapsedNs int64      `json:"elapsed_ns"`
+	Error     string     `json:"error,omitempty"`
+}
+
+func newResultRecord(r aoc.Result) resultRecord {
+	rec := resultRecord{
+		Day:       r.Day,
+		Part:      r.Part,
+		Input:     r.Input,
+		Answer:    r.Answer,
+		ElapsedNs: r.Elapsed.Nanoseconds(),
+	}
+	if r.Err != nil {
+		rec.Answer = nil
+		rec.Error = r.Err.Error()
+	}
+	return rec
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// textWriter is the human-readable "6.1: 41" style, or "6.1 [6/6.txt]: 41"
+// when labelling inputs
+type textWriter struct {
+	out, errOut io.Writer
+	opts        outputOptions
+}
+
+// label is "6.1", or "6" for a whole day, plus the input when labelling them
+func (w *textWriter) label(day int, part string, input string) string {
+	label := strconv.Itoa(day)
+	if part != "" {
+		label += "." + part
+	}
+	if w.opts.LabelInputs {
+		label += " [" + input + "]"
+	}
+	return label
+}
+
+func (w *textWriter) Write(r aoc.Result) error {
+	label := w.label(r.Day, strconv.Itoa(r.Part), r.Input)
+	if r.Err != nil {
+		_, err := fmt.Fprintf(w.errOut, "%s: error: %s\n", label, r.Err.Error())
+		return err
+	}
+	timing := ""
+	if w.opts.Times {
+		timing = fmt.Sprintf("  (%s)", r.Elapsed.Round(time.Microsecond))
+	}
+	_, err := fmt.Fprintf(w.out, "%s: %v%s\n", label, r.Answer, timing)
+	return err
+}
+
+func (w *textWriter) DayDone(day int, input string, total time.Duration) error {
+	if !w.opts.Times {
+		return nil
+	}
+	_, err := fmt.Fprintf(w.out, "%s: total %s\n", w.label(day, "", input), total.Round(time.Microsecond))
+	return err
+}
+
+func (w *textWriter) Close() error { return nil }
+
+// ndjsonWriter emits one JSON record per line as results arrive
+type ndjsonWriter struct {
+	enc *json.Encoder
+}
+
+func (w *ndjsonWriter) Write(r aoc.Result) error { return w.enc.Encode(newResultRecord(r)) }
+
+func (w *ndjsonWriter) DayDone(int, string, time.Duration) error { return nil }
+
+func (w *ndjsonWriter) Close() error { return nil }
+
+// jsonWriter collects results into a single JSON document, written on Close
+type jsonWriter struct {
+	out     io.Writer
+	records []resultRecord
+}
+
+func (w *jsonWriter) Write(r aoc.Result) error {
+	w.records = append(w.records, newResultRecord(r))
+	return nil
+}
+
+func (w *jsonWriter) DayDone(int, string, time.Duration) error { return nil }
+
+func (w *jsonWriter) Close() error {
+	records := w.records
+	if records == nil {
+		records = []resultRecord{}
+	}
+	data, err := json.MarshalIndent(struct {
+		Results []resultRecord `json:"results"`
+	}{records}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.out.Write(append(data, '\n'))
+	return err
+}
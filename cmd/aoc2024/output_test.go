@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+func TestResultWriters(t *testing.T) {
+	results := []aoc.Result{
+		{Day: 6, Part: 1, Input: "6/6.test.txt", Answer: 41, Elapsed: 1500 * time.Nanosecond},
+		{Day: 6, Part: 2, Input: "6/6.test.txt", Err: errors.New("6/6.test.txt:3: boom")},
+	}
+	tests := []struct {
+		format      string
+		label       bool
+		out, errOut string
+	}{
+		{"text", false, "6.1: 41\n", "6.2: error: 6/6.test.txt:3: boom\n"},
+		{"text", true, "6.1 [6/6.test.txt]: 41\n", "6.2 [6/6.test.txt]: error: 6/6.test.txt:3: boom\n"},
+		{"ndjson", false, `{"day":6,"part":1,"input":"6/6.test.txt","answer":41,"elapsed_ns":1500}
+{"day":6,"part":2,"input":"6/6.test.txt","elapsed_ns":0,"error":"6/6.test.txt:3: boom"}
+`, ""},
+	}
+	for _, tt := range tests {
+		var out, errOut bytes.Buffer
+		w, err := newResultWriter(tt.format, &out, &errOut, outputOptions{LabelInputs: tt.label})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, r := range results {
+			if err := w.Write(r); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if out.String() != tt.out || errOut.String() != tt.errOut {
+			t.Errorf("%s: got %q / %q, want %q / %q", tt.format, out.String(), errOut.String(), tt.out, tt.errOut)
+		}
+	}
+
+	var out bytes.Buffer
+	w, _ := newResultWriter("json", &out, nil, outputOptions{})
+	w.Close()
+	if !strings.Contains(out.String(), `"results": []`) {
+		t.Errorf("empty json: got %q", out.String())
+	}
+	if _, err := newResultWriter("yaml", nil, nil, outputOptions{}); err == nil {
+		t.Error("expected an error for a bad format")
+	}
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"gopkg.in/yaml.v3"
+)
+
+// dayParams are puzzle parameters by day
+type dayParams map[int]aoc.Params
+
+// paramFlags are the flags setting puzzle parameters, for commands that
+// solve or simulate days
+type paramFlags struct {
+	fs     *flag.FlagSet
+	pairs  stringsFlag
+	config *string
+}
+
+func addParamFlags(fs *flag.FlagSet) *paramFlags {
+	pf := &paramFlags{fs: fs}
+	fs.Var(&pf.pairs, "param", "set a puzzle `parameter`, as [day.]name=value; may be repeated, see list --params")
+	pf.config = fs.String("config", "", "YAML `file` of puzzle parameters by day, overridden by --param")
+	return pf
+}
+
+// read returns the parameters the flags set, checking days can take them
+func (pf *paramFlags) read(days []*aoc.Day) (dayParams, error) {
+	params := make(dayParams)
+	if *pf.config != "" {
+		var err error
+		if params, err = readParamsConfig(*pf.config); err != nil {
+			return nil, err
+		}
+	}
+	if err := params.setFlags(days, pf.pairs); err != nil {
+		return nil, badUsage(pf.fs, "%s", err)
+	}
+	if err := params.check(days); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// readParamsConfig reads a YAML file of parameters by day, e.g.
+//
+//	14:
+//	  room: 11x7
+//	  steps: 100
+func readParamsConfig(path string) (dayParams, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var config map[int]map[string]any
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	dp := make(dayParams)
+	for day, values := range config {
+		for name, value := range values {
+			dp.set(day, name, fmt.Sprint(value))
+		}
+	}
+	return dp, nil
+}
+
+func (dp dayParams) set(day int, name, value string) {
+	if dp[day] == nil {
+		dp[day] = make(aoc.Params)
+	}
+	dp[day][name] = value
+}
+
+// setFlags adds --param values, each "name=value" for the only day in days,
+// or "N.name=value" for day N, which must be one of days
+func (dp dayParams) setFlags(days []*aoc.Day, pairs []string) error {
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("bad --param %q, expected [day.]name=value", pair)
+		}
+		day := 0
+		if prefix, rest, ok := strings.Cut(name, "."); ok {
+			n, err := strconv.Atoi(prefix)
+			if err != nil {
+				return fmt.Errorf("bad --param %q, expected [day.]name=value", pair)
+			}
+			if !slices.ContainsFunc(days, func(d *aoc.Day) bool { return d.Day == n }) {
+				return fmt.Errorf("--param %q is for day %d, which isn't being solved", pair, n)
+			}
+			day, name = n, rest
+		} else if len(days) == 1 {
+			day = days[0].Day
+		} else {
+			return fmt.Errorf("--param %q needs its day, e.g. %d.%s, when solving several days", pair, days[0].Day, pair)
+		}
+		dp.set(day, name, value)
+	}
+	return nil
+}
+
+// check reports the first parameter that days don't have, or can't take
+func (dp dayParams) check(days []*aoc.Day) error {
+	for _, d := range days {
+		if _, err := d.NewSolver(dp[d.Day]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
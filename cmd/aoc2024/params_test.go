@@ -0,0 +1,69 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+func TestDayParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "params.yaml")
+	config := "14:\n  room: 11x7\n  spread: 2.5\n11:\n  blinks1: 6\n"
+	if err := os.WriteFile(path, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	dp, err := readParamsConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	day11, day14 := &aoc.Day{Day: 11}, &aoc.Day{Day: 14}
+	if err := dp.setFlags([]*aoc.Day{day11, day14}, []string{"14.steps=50", "11.blinks1=1"}); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := dp[14].String(), "room=11x7 spread=2.5 steps=50"; got != want {
+		t.Errorf("day 14: got %q, want %q", got, want)
+	}
+	if got, want := dp[11].String(), "blinks1=1"; got != want {
+		t.Errorf("day 11: got %q, want %q", got, want)
+	}
+
+	for _, bad := range []string{"steps", "x.steps=1", "=1", "11.blinks1=1"} {
+		if err := dp.setFlags([]*aoc.Day{day14}, []string{bad}); err == nil {
+			t.Errorf("setFlags(%q): want an error", bad)
+		}
+	}
+	if err := dp.setFlags([]*aoc.Day{day11, day14}, []string{"steps=1"}); err == nil {
+		t.Error("setFlags without a day, for several days: want an error")
+	}
+}
+
+func TestParamFlags(t *testing.T) {
+	day14, ok := aoc.Lookup(14)
+	if !ok {
+		t.Fatal("day 14 is not registered")
+	}
+	fs := newFlagSet("test", "test")
+	pf := addParamFlags(fs)
+	if err := fs.Parse([]string{"--config", "../../test.params.yaml", "--param", "steps=5"}); err != nil {
+		t.Fatal(err)
+	}
+	dp, err := pf.read([]*aoc.Day{day14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := dp[14].String(), "room=11x7 steps=5"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	fs = newFlagSet("test", "test")
+	pf = addParamFlags(fs)
+	if err := fs.Parse([]string{"--param", "room=11"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := pf.read([]*aoc.Day{day14}); err == nil {
+		t.Error("read of a bad room: want an error")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+// job is one part of one day to solve against one input
+type job struct {
+	day   *aoc.Day
+	part  int
+	path  string // input name
+	input string
+
+	params    aoc.Params       // configure the solver
+	snapshots aoc.SnapshotFunc // receives the solver's snapshots, if set
+	hook      aoc.SolveHook    // wraps solving, if set
+	big       bool             // compute with math/big where the day can
+}
+
+// runJobs solves jobs on up to parallel workers.  emit is called with each
+// job's index and result in job order, as soon as it and every job before
+// it are done.
+// Each job gets its own solver, so parts of a day run independently.
+func runJobs(jobs []job, parallel int, timeout time.Duration, emit func(i int, r aoc.Result)) {
+	results := make([]aoc.Result, len(jobs))
+	done := make([]chan struct{}, len(jobs))
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	work := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < parallel; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range work {
+				ctx, cancel := dayContext(timeout)
+				ctx = aoc.WithParams(ctx, jobs[i].params)
+				if jobs[i].snapshots != nil {
+					ctx = aoc.WithSnapshots(ctx, jobs[i].snapshots)
+				}
+				if jobs[i].hook != nil {
+					ctx = aoc.WithSolveHook(ctx, jobs[i].hook)
+				}
+				if jobs[i].big {
+					ctx = aoc.WithBig(ctx)
+				}
+				results[i] = jobs[i].day.Run(ctx, jobs[i].input, jobs[i].part)[0]
+				cancel()
+				close(done[i])
+			}
+		}()
+	}
+	go func() {
+		for i := range jobs {
+			work <- i
+		}
+		close(work)
+	}()
+
+	for i := range jobs {
+		<-done[i]
+		emit(i, results[i])
+	}
+	wg.Wait()
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+// sleepSolver sleeps for its input's milliseconds, answering with them,
+// and panics on part 2
+type sleepSolver struct{ ms int }
+
+func (s *sleepSolver) Parse(r io.Reader) (err error) {
+	data, _ := io.ReadAll(r)
+	s.ms, err = strconv.Atoi(string(data))
+	return err
+}
+
+func (s *sleepSolver) Part1(ctx context.Context) (aoc.Answer, error) {
+	time.Sleep(time.Duration(s.ms) * time.Millisecond)
+	return s.ms, nil
+}
+
+func (s *sleepSolver) Part2(ctx context.Context) (aoc.Answer, error) { panic("boom") }
+
+func TestRunJobs(t *testing.T) {
+	d := &aoc.Day{Day: 1, New: func() aoc.Solver { return &sleepSolver{} }}
+	var jobs []job
+	for _, ms := range []string{"30", "1", "20", "x"} {
+		jobs = append(jobs, job{day: d, part: 1, input: ms}, job{day: d, part: 2, input: ms})
+	}
+
+	var got []aoc.Result
+	runJobs(jobs, 4, 0, func(i int, r aoc.Result) {
+		if i != len(got) {
+			t.Errorf("emitted job %d out of order, after %d", i, len(got))
+		}
+		got = append(got, r)
+	})
+	if len(got) != len(jobs) {
+		t.Fatalf("got %d results, want %d", len(got), len(jobs))
+	}
+	for i, r := range got {
+		var pe *aoc.PanicError
+		switch {
+		case jobs[i].input == "x":
+			if r.Err == nil {
+				t.Errorf("job %d: expected a parse error", i)
+			}
+		case jobs[i].part == 2:
+			if !errors.As(r.Err, &pe) {
+				t.Errorf("job %d: got %v, want a panic", i, r.Err)
+			}
+		case strconv.Itoa(r.Answer.(int)) != jobs[i].input:
+			t.Errorf("job %d: got %v, want %s", i, r.Answer, jobs[i].input)
+		}
+	}
+}
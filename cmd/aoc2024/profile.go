@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+	"sync"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+// profiler writes CPU and heap profiles and execution traces of each part
+// solved, as e.g. 6.2.cpu.pprof, into the directories that are set.
+// CPU profiles and traces are process-wide, so parts must be solved one at
+// a time.
+type profiler struct {
+	cpuDir, memDir, traceDir string
+
+	mu  sync.Mutex
+	err error // the first failure
+}
+
+// newProfiler makes the directories that are set, returning nil if none are
+func newProfiler(cpuDir, memDir, traceDir string) (*profiler, error) {
+	if cpuDir == "" && memDir == "" && traceDir == "" {
+		return nil, nil
+	}
+	for _, dir := range []string{cpuDir, memDir, traceDir} {
+		if dir == "" {
+			continue
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+	return &profiler{cpuDir: cpuDir, memDir: memDir, traceDir: traceDir}, nil
+}
+
+// profileName is the file name of a profile: e.g. "6.2.cpu.pprof", or
+// "6.2.6.test.cpu.pprof" when labelled with its input
+func profileName(day, part int, input, kind string) string {
+	name := fmt.Sprintf("%d.%d", day, part)
+	if input != "" {
+		name += "." + inputLabel(input)
+	}
+	return name + "." + kind
+}
+
+// Hook returns a SolveHook profiling parts solved against input, which
+// labels the files if it is set
+func (p *profiler) Hook(input string) aoc.SolveHook {
+	return func(day, part int) func() {
+		path := func(dir, kind string) string {
+			return filepath.Join(dir, profileName(day, part, input, kind))
+		}
+
+		var cpuFile, traceFile *os.File
+		if p.cpuDir != "" {
+			cpuFile = p.create(path(p.cpuDir, "cpu.pprof"))
+			if cpuFile != nil {
+				p.fail(pprof.StartCPUProfile(cpuFile))
+			}
+		}
+		if p.traceDir != "" {
+			traceFile = p.create(path(p.traceDir, "trace.out"))
+			if traceFile != nil {
+				p.fail(trace.Start(traceFile))
+			}
+		}
+
+		return func() {
+			if cpuFile != nil {
+				pprof.StopCPUProfile()
+				p.fail(cpuFile.Close())
+			}
+			if traceFile != nil {
+				trace.Stop()
+				p.fail(traceFile.Close())
+			}
+			if p.memDir != "" {
+				if f := p.create(path(p.memDir, "mem.pprof")); f != nil {
+					runtime.GC() // the heap as the part left it
+					p.fail(pprof.WriteHeapProfile(f))
+					p.fail(f.Close())
+				}
+			}
+		}
+	}
+}
+
+func (p *profiler) create(path string) *os.File {
+	f, err := os.Create(path)
+	p.fail(err)
+	return f
+}
+
+// fail notes err if it is the first failure
+func (p *profiler) fail(err error) {
+	if err == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.err == nil {
+		p.err = err
+	}
+}
+
+// Err returns the first failure to write a profile
+func (p *profiler) Err() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.err
+}
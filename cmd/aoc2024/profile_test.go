@@ -0,0 +1,32 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestProfiler(t *testing.T) {
+	if p, err := newProfiler("", "", ""); p != nil || err != nil {
+		t.Errorf("no directories: got %v, %v, want no profiler", p, err)
+	}
+
+	dir := t.TempDir()
+	p, err := newProfiler(filepath.Join(dir, "cpu"), dir, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Hook("")(6, 2)()
+	p.Hook("6/6.test.txt")(6, 1)()
+	if err := p.Err(); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{
+		"cpu/6.2.cpu.pprof", "6.2.mem.pprof", "6.2.trace.out",
+		"cpu/6.1.6.test.cpu.pprof", "6.1.6.test.mem.pprof", "6.1.6.test.trace.out",
+	} {
+		if fi, err := os.Stat(filepath.Join(dir, name)); err != nil || fi.Size() == 0 {
+			t.Errorf("%s: want a profile, got %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+	"github.com/neomantra/aoc2024/export"
+	"github.com/neomantra/aoc2024/parse"
+	"github.com/neomantra/aoc2024/replay"
+	"github.com/neomantra/aoc2024/replay/viewer"
+)
+
+func replayCmd(args []string) error {
+	fs := newFlagSet("replay", "replay <day> [input] [flags]\n\n"+
+		"Plays back a day's simulation in the terminal, e.g. day 6's guard walk,\n"+
+		"or with --export-dir, saves it as an animated GIF.")
+	testFlag := fs.Bool("test", false, "use the example input N/N.test.txt")
+	framesFlag := fs.Int("frames", replay.DefaultMaxFrames, "keep at most `N` frames, evenly spread over long runs")
+	exportDirFlag := fs.String("export-dir", "", "instead of playing, write the replay to `dir`/N.replay.gif")
+	exportScaleFlag := fs.Int("export-scale", 8, "GIF `pixels` per grid cell")
+	fpsFlag := fs.Int("fps", 10, "GIF `frames` per second")
+	paramFlags := addParamFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 || len(positional) > 2 {
+		return badUsage(fs, "replay expects a day and optionally an input, got %d arguments", len(positional))
+	}
+	if len(positional) == 2 && *testFlag {
+		return badUsage(fs, "--test and an input file are exclusive")
+	}
+
+	days, err := aoc.Select(positional[0])
+	if err != nil {
+		return err
+	}
+	if len(days) != 1 {
+		return badUsage(fs, "replay expects one day")
+	}
+	d := days[0]
+	if d.Replay == nil {
+		return fmt.Errorf("day %d has no simulation to replay", d.Day)
+	}
+	params, err := paramFlags.read(days)
+	if err != nil {
+		return err
+	}
+	explicit := ""
+	if len(positional) == 2 {
+		explicit = positional[1]
+	}
+	path := inputPath(d.Day, explicit, *testFlag)
+	input, err := readInput(path)
+	if err != nil {
+		return err
+	}
+
+	rec := replay.NewRecorder(*framesFlag)
+	if err := d.Replay(aoc.WithParams(context.Background(), params[d.Day]), input, rec); err != nil {
+		return parse.Named(err, inputName(path))
+	}
+	if *exportDirFlag != "" {
+		return exportReplay(*exportDirFlag, d.Day, rec.Frames(), *exportScaleFlag, *fpsFlag)
+	}
+	return viewer.Play(fmt.Sprintf("Day %d [%s]", d.Day, inputName(path)), rec.Frames())
+}
+
+// exportReplay writes frames as dir/N.replay.gif
+func exportReplay(dir string, day int, frames []replay.Frame, scale, fps int) error {
+	if fps <= 0 {
+		return fmt.Errorf("bad --fps %d", fps)
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%d.replay.gif", day))
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := export.GIF(f, frames, export.DefaultPalette, scale, time.Second/time.Duration(fps)); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("wrote %d frames to %s\n", len(frames), path)
+	return nil
+}
@@ -0,0 +1,250 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+func serveCmd(args []string) error {
+	fs := newFlagSet("serve", "serve [flags]\n\n"+
+		"Serves the solvers over HTTP:\n"+
+		"  GET  /                            a dashboard of answers, timings and grids\n"+
+		"  GET  /days                        the days, their parts and puzzle parameters\n"+
+		"  GET  /days/{n}/example            the example N/N.test.txt\n"+
+		"  POST /days/{n}/parts/{p}          solve a part of the puzzle text in the body,\n"+
+		"                                    with puzzle parameters as query parameters\n"+
+		"  POST /days/{n}/parts/{p}/grids    the same, also answering with its snapshots\n"+
+		"  POST /days/{n}/replay?frames=N    record the simulation of the puzzle in the body")
+	addrFlag := fs.String("addr", "localhost:8080", "listen on `address`")
+	maxInputFlag := fs.Int64("max-input", 1<<20, "largest puzzle accepted, in `bytes`")
+	timeoutFlag := fs.Duration("timeout", 30*time.Second, "give up on a part after `duration`")
+	parallelFlag := fs.Int("parallel", 0, "solve up to `N` parts at once, 0 for one per CPU")
+	dirFlag := fs.String("dir", ".", "serve examples from `dir`/N/N.test.txt")
+	logFlags := addLogFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return badUsage(fs, "serve takes no arguments")
+	}
+	if *timeoutFlag <= 0 {
+		return badUsage(fs, "--timeout must be positive")
+	}
+	if err := logFlags.setup(os.Stderr); err != nil {
+		return err
+	}
+
+	srv := newServer(*maxInputFlag, *timeoutFlag, *parallelFlag)
+	srv.examples = os.DirFS(*dirFlag)
+	httpServer := &http.Server{
+		Addr:              *addrFlag,
+		Handler:           srv.routes(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       time.Minute,
+		WriteTimeout:      *timeoutFlag + time.Minute, // queueing, solving and replying
+	}
+	fmt.Fprintf(os.Stderr, "serving on http://%s\n", *addrFlag)
+	return httpServer.ListenAndServe()
+}
+
+// server solves puzzles posted over HTTP
+type server struct {
+	maxInput int64
+	timeout  time.Duration
+	slots    chan struct{} // one per part being solved
+	examples fs.FS         // holding N/N.test.txt
+}
+
+func newServer(maxInput int64, timeout time.Duration, parallel int) *server {
+	if parallel <= 0 {
+		parallel = runtime.NumCPU()
+	}
+	return &server{maxInput: maxInput, timeout: timeout, slots: make(chan struct{}, parallel), examples: os.DirFS(".")}
+}
+
+func (s *server) routes() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.Handle("GET /", s.dashboard())
+	mux.HandleFunc("GET /days", s.listDays)
+	mux.HandleFunc("GET /days/{n}/example", s.example)
+	mux.HandleFunc("POST /days/{n}/parts/{p}", s.solve)
+	mux.HandleFunc("POST /days/{n}/parts/{p}/grids", s.solveGrids)
+	mux.HandleFunc("POST /days/{n}/replay", s.replay)
+	return mux
+}
+
+// dayRecord is the JSON form of a registered day
+type dayRecord struct {
+	Day    int               `json:"day"`
+	Parts  []int             `json:"parts"`
+	Params map[string]string `json:"params,omitempty"` // with their defaults
+	Replay bool              `json:"replay,omitempty"` // has a simulation to replay
+}
+
+func (s *server) listDays(w http.ResponseWriter, r *http.Request) {
+	var days []dayRecord
+	for _, d := range aoc.Days() {
+		rec := dayRecord{Day: d.Day, Parts: []int{1}, Replay: d.Replay != nil}
+		if d.HasPart(2) {
+			rec.Parts = append(rec.Parts, 2)
+		}
+		if params := d.ParamFlags(); params != nil {
+			rec.Params = make(map[string]string)
+			params.VisitAll(func(f *flag.Flag) { rec.Params[f.Name] = f.DefValue })
+		}
+		days = append(days, rec)
+	}
+	writeJSON(w, http.StatusOK, days)
+}
+
+// solveRequest reads the day, part, puzzle parameters and puzzle of a
+// request to solve, or writes the error response and returns ok false
+func (s *server) solveRequest(w http.ResponseWriter, r *http.Request) (d *aoc.Day, part int, params aoc.Params, input string, ok bool) {
+	d, ok = s.dayRequest(w, r)
+	if !ok {
+		return nil, 0, nil, "", false
+	}
+	part, err := strconv.Atoi(r.PathValue("p"))
+	if err != nil || !d.HasPart(part) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("day %d has no part %q", d.Day, r.PathValue("p")))
+		return nil, 0, nil, "", false
+	}
+
+	params = make(aoc.Params)
+	for name, values := range r.URL.Query() {
+		params[name] = values[len(values)-1]
+	}
+	if _, err := d.NewSolver(params); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return nil, 0, nil, "", false
+	}
+
+	input, ok = s.readPuzzle(w, r)
+	return d, part, params, input, ok
+}
+
+// dayRequest looks up the day of a request, or writes the error response
+func (s *server) dayRequest(w http.ResponseWriter, r *http.Request) (*aoc.Day, bool) {
+	n, err := strconv.Atoi(r.PathValue("n"))
+	d, ok := aoc.Lookup(n)
+	if err != nil || !ok {
+		writeError(w, http.StatusNotFound, fmt.Errorf("no day %q", r.PathValue("n")))
+		return nil, false
+	}
+	return d, true
+}
+
+// readPuzzle reads the puzzle in a request's body, up to maxInput bytes,
+// or writes the error response
+func (s *server) readPuzzle(w http.ResponseWriter, r *http.Request) (string, bool) {
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, s.maxInput))
+	var tooBig *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooBig):
+		writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("puzzle is over %d bytes", s.maxInput))
+		return "", false
+	case err != nil:
+		writeError(w, http.StatusBadRequest, err)
+		return "", false
+	}
+	return strings.TrimRight(string(data), "\r\n"), true
+}
+
+// errBusy is when no solving slot frees up in time
+var errBusy = errors.New("too busy to solve it in time")
+
+// run calls fn in a solving slot, giving up when ctx is done.  A solver
+// that ignores ctx keeps its slot until it finishes, but the request still
+// gets its answer in time.
+func (s *server) run(ctx context.Context, fn func()) error {
+	select {
+	case s.slots <- struct{}{}:
+	case <-ctx.Done():
+		return errBusy
+	}
+	done := make(chan struct{})
+	go func() {
+		defer func() { <-s.slots }()
+		fn()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		err := ctx.Err()
+		if errors.Is(err, context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s: %w", s.timeout, err)
+		}
+		return err
+	}
+}
+
+// runPart solves a part of input in a solving slot, within ctx
+func (s *server) runPart(ctx context.Context, d *aoc.Day, input string, part int) aoc.Result {
+	var solved aoc.Result // only read if the solver finished
+	if err := s.run(ctx, func() { solved = d.Run(ctx, input, part)[0] }); err != nil {
+		return aoc.Result{Day: d.Day, Part: part, Elapsed: s.timeout, Err: err}
+	}
+	return solved
+}
+
+// errorStatus is the response status for a solving error
+func errorStatus(err error) int {
+	var pe *aoc.PanicError
+	switch {
+	case err == nil:
+		return http.StatusOK
+	case errors.Is(err, errBusy):
+		return http.StatusServiceUnavailable
+	case errors.Is(err, context.DeadlineExceeded):
+		return http.StatusGatewayTimeout
+	case errors.As(err, &pe):
+		aoc.Log.Error("solver panicked", "panic", pe.Value, "stack", string(pe.Stack))
+		return http.StatusInternalServerError
+	default:
+		return http.StatusUnprocessableEntity // the solver couldn't make sense of it
+	}
+}
+
+func (s *server) solve(w http.ResponseWriter, r *http.Request) {
+	d, part, params, input, ok := s.solveRequest(w, r)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(aoc.WithParams(r.Context(), params), s.timeout)
+	defer cancel()
+	result := s.runPart(ctx, d, input, part)
+	if errors.Is(result.Err, context.Canceled) {
+		return // the client went away
+	}
+	status := errorStatus(result.Err)
+	aoc.Log.Info("solved", "day", d.Day, "part", part, "bytes", len(input), "status", status, "elapsed", result.Elapsed)
+	writeJSON(w, status, newResultRecord(result))
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
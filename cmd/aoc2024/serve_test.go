@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	srv := httptest.NewServer(newServer(100, 200*time.Millisecond, 2).routes())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL + "/days")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var days []dayRecord
+	if err := json.NewDecoder(resp.Body).Decode(&days); err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	found := false
+	for _, d := range days {
+		if d.Day == 14 {
+			found = d.Params["room"] == "101x103" && len(d.Parts) == 2
+		}
+	}
+	if !found {
+		t.Errorf("GET /days: day 14 missing, or without its parts and params, in %+v", days)
+	}
+
+	day1 := "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n"
+	robots := "p=0,4 v=3,-3\np=6,3 v=-1,-3\n"
+	tests := []struct {
+		path, input string
+		status      int
+		want        string // in the response
+	}{
+		{"/days/1/parts/1", day1, http.StatusOK, `"answer":11`},
+		{"/days/1/parts/2", day1, http.StatusOK, `"answer":31`},
+		{"/days/14/parts/1?room=11x7", robots, http.StatusOK, `"answer":`},
+		{"/days/14/parts/1?size=3", robots, http.StatusBadRequest, `no parameter`},
+		{"/days/14/parts/2?room=11x7&spread=0", robots, http.StatusGatewayTimeout, `timed out`},
+		{"/days/1/parts/1", "1 2\nx y\n", http.StatusUnprocessableEntity, `"error":`},
+		{"/days/1/parts/1", strings.Repeat("1   2\n", 20), http.StatusRequestEntityTooLarge, `over 100 bytes`},
+		{"/days/16/parts/1", day1, http.StatusNotFound, `no day`},
+		{"/days/12/parts/2", day1, http.StatusNotFound, `no part`},
+	}
+	for _, tt := range tests {
+		resp, err := http.Post(srv.URL+tt.path, "text/plain", strings.NewReader(tt.input))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var body strings.Builder
+		_, err = io.Copy(&body, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != tt.status || !strings.Contains(body.String(), tt.want) {
+			t.Errorf("POST %s: got %d %s, want %d with %s", tt.path, resp.StatusCode, body.String(), tt.status, tt.want)
+		}
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	s := newServer(1<<20, 5*time.Second, 2)
+	guard := "....#.....\n.........#\n..........\n..#.......\n.......#..\n..........\n.#..^.....\n........#.\n#.........\n......#...\n"
+	s.examples = fstest.MapFS{"6/6.test.txt": {Data: []byte(guard)}}
+	srv := httptest.NewServer(s.routes())
+	defer srv.Close()
+
+	get := func(path string) (int, string) {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return resp.StatusCode, string(body)
+	}
+	if status, body := get("/"); status != http.StatusOK || !strings.Contains(body, "app.js") {
+		t.Errorf("GET /: got %d %.60s", status, body)
+	}
+	if status, body := get("/app.js"); status != http.StatusOK || !strings.Contains(body, "/days") {
+		t.Errorf("GET /app.js: got %d %.60s", status, body)
+	}
+	if status, body := get("/days/6/example"); status != http.StatusOK || body != guard {
+		t.Errorf("GET /days/6/example: got %d %q", status, body)
+	}
+	if status, _ := get("/days/1/example"); status != http.StatusNotFound {
+		t.Errorf("GET /days/1/example: got %d, want %d", status, http.StatusNotFound)
+	}
+
+	resp, err := http.Post(srv.URL+"/days/6/parts/1/grids", "text/plain", strings.NewReader(guard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var solved gridsRecord
+	err = json.NewDecoder(resp.Body).Decode(&solved)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if solved.Answer != float64(41) || len(solved.Grids) != 1 || solved.Grids[0].Name != "path" ||
+		len(solved.Grids[0].Rows) != 10 || solved.Colors["#"] == "" {
+		t.Errorf("POST /days/6/parts/1/grids: got %+v", solved)
+	}
+
+	resp, err = http.Post(srv.URL+"/days/6/replay?frames=5", "text/plain", strings.NewReader(guard))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var replayed replayRecord
+	err = json.NewDecoder(resp.Body).Decode(&replayed)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := len(replayed.Frames); n < 2 || n > 6 || replayed.Steps < n || len(replayed.Frames[0].Rows) != 10 {
+		t.Errorf("POST /days/6/replay: got %d frames of %d steps", n, replayed.Steps)
+	}
+
+	// puzzle parameters size day 14's room
+	resp, err = http.Post(srv.URL+"/days/14/replay?frames=5&room=11x7", "text/plain", strings.NewReader("p=10,6 v=1,1\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replayed = replayRecord{}
+	err = json.NewDecoder(resp.Body).Decode(&replayed)
+	resp.Body.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed.Frames) == 0 || len(replayed.Frames[0].Rows) != 7 || len(replayed.Frames[0].Rows[0]) != 11 {
+		t.Errorf("POST /days/14/replay?room=11x7: got %+v", replayed)
+	}
+
+	for path, status := range map[string]int{
+		"/days/6/replay?frames=0":  http.StatusBadRequest,
+		"/days/14/replay?room=11":  http.StatusBadRequest,
+		"/days/6/replay?room=11x7": http.StatusBadRequest,
+		"/days/1/replay":           http.StatusNotFound,
+	} {
+		resp, err := http.Post(srv.URL+path, "text/plain", strings.NewReader(guard))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != status {
+			t.Errorf("POST %s: got %d, want %d", path, resp.StatusCode, status)
+		}
+	}
+}
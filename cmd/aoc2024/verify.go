@@ -0,0 +1,75 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+// verifyDays cross-checks each day that can be verified on each input,
+// with its params, exiting 1 if any disagree
+func verifyDays(days []*aoc.Day, inputs []string, test bool, timeout time.Duration, params dayParams) error {
+	if len(days) == 1 && !days[0].CanVerify() {
+		return fmt.Errorf("day %d has no naive implementation to verify against", days[0].Day)
+	}
+	failed := false
+	for _, d := range days {
+		if !d.CanVerify() {
+			continue
+		}
+		for _, explicit := range inputs {
+			path, input, err := loadInput(nil, d.Day, explicit, test)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading %s: %s\n", inputName(path), err.Error())
+				failed = true
+				continue
+			}
+			if !verifyInput(d, inputName(path), input, timeout, params[d.Day]) {
+				failed = true
+			}
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+	return nil
+}
+
+// verifyInput cross-checks d's implementations on input, with params,
+// reporting on stdout.  On a mismatch, it also shrinks the input to the
+// smallest that still disagrees, so the bug is easy to see.
+func verifyInput(d *aoc.Day, name, input string, timeout time.Duration, params aoc.Params) bool {
+	verify := func(input string) error {
+		ctx, cancel := dayContext(timeout)
+		defer cancel()
+		return d.Verify(aoc.WithParams(ctx, params), input)
+	}
+	var m *aoc.Mismatch
+	err := verify(input)
+	switch {
+	case err == nil:
+		fmt.Printf("%d [%s]: ok\n", d.Day, name)
+		return true
+	case !errors.As(err, &m):
+		fmt.Fprintf(os.Stderr, "%d [%s]: error: %s\n", d.Day, name, err.Error())
+		return false
+	}
+
+	fmt.Printf("%d [%s]: mismatch: %s\n", d.Day, name, m)
+	smallest := aoc.Shrink(input, func(input string) bool {
+		return errors.As(verify(input), &m)
+	})
+	// shrinking leaves m from the last candidate tried, so check again
+	if errors.As(verify(smallest), &m) {
+		fmt.Printf("smallest disagreeing input, %s:\n", m)
+	}
+	fmt.Print(smallest)
+	if !strings.HasSuffix(smallest, "\n") {
+		fmt.Println()
+	}
+	return false
+}
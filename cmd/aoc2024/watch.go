@@ -0,0 +1,283 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+func watchCmd(args []string) error {
+	fs := newFlagSet("watch", "watch <day> [input...] [flags]\n\n"+
+		"Solves a day's examples, those in N/N.answers.txt, and N/N.txt if there is one,\n"+
+		"or the given inputs, then again whenever an input or the answers file changes,\n"+
+		"showing each answer against the last run's and the expected one.  Changes to\n"+
+		"the day's source are noticed, but need a rebuild to solve with.  Runs until\n"+
+		"interrupted.")
+	intervalFlag := fs.Duration("interval", 500*time.Millisecond, "how often to check the files for changes")
+	timeoutFlag := fs.Duration("timeout", 0, "give up on a part after `duration`, e.g. 30s (default no limit)")
+	var paramFlag stringsFlag
+	fs.Var(&paramFlag, "param", "set a puzzle `parameter`, as name=value, over the examples'; may be repeated")
+	logFlags := addLogFlags(fs)
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) < 1 {
+		return badUsage(fs, "watch expects a day")
+	}
+	day, err := strconv.Atoi(positional[0])
+	if err != nil {
+		return badUsage(fs, "bad day %q", positional[0])
+	}
+	d, ok := aoc.Lookup(day)
+	if !ok {
+		return fmt.Errorf("day %d is not registered", day)
+	}
+	params, err := aoc.ParseParams(paramFlag)
+	if err != nil {
+		return err
+	}
+	if _, err := d.NewSolver(params); err != nil {
+		return err
+	}
+	if err := logFlags.setup(os.Stderr); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	w := newWatcher(".", d, positional[1:], params, *timeoutFlag)
+	for {
+		w.check(ctx, os.Stdout)
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(*intervalFlag):
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// watcher solves a day's inputs again when they change, comparing answers
+// with the last run's and the expected ones
+type watcher struct {
+	dir      string // holding the day's directory
+	day      *aoc.Day
+	explicit []string      // inputs to solve, else the examples and N/N.txt
+	params   aoc.Params    // over each example's own
+	timeout  time.Duration // per part, if set
+
+	stamps   map[string]stamp  // of the files as last checked
+	previous map[string]string // answers last run, by input and part
+}
+
+// stamp is what checking compares to tell that a file changed
+type stamp struct {
+	size    int64
+	modTime time.Time
+}
+
+// watchedInput is an input to solve, and what's expected of it
+type watchedInput struct {
+	path   string
+	params aoc.Params
+	want   map[int]string // answers by part, if known
+}
+
+func newWatcher(dir string, d *aoc.Day, explicit []string, params aoc.Params, timeout time.Duration) *watcher {
+	for i := range explicit {
+		explicit[i] = filepath.Clean(explicit[i])
+	}
+	return &watcher{
+		dir:      dir,
+		day:      d,
+		explicit: explicit,
+		params:   params,
+		timeout:  timeout,
+		stamps:   make(map[string]stamp),
+		previous: make(map[string]string),
+	}
+}
+
+// dayPath returns the path of name in the day's directory
+func (w *watcher) dayPath(name string) string {
+	return filepath.Join(w.dir, strconv.Itoa(w.day.Day), name)
+}
+
+// inputs returns the inputs to solve, with the expected answers from the
+// answers file, or an error reading it
+func (w *watcher) inputs() ([]watchedInput, error) {
+	answers, err := aoc.ReadAnswers(w.dayPath(aoc.AnswersPath(w.day.Day)))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	var inputs []watchedInput
+	add := func(path string) *watchedInput {
+		path = filepath.Clean(path)
+		for i := range inputs {
+			if inputs[i].path == path {
+				return &inputs[i]
+			}
+		}
+		inputs = append(inputs, watchedInput{path: path, params: make(aoc.Params), want: make(map[int]string)})
+		return &inputs[len(inputs)-1]
+	}
+
+	for _, path := range w.explicit {
+		add(path)
+	}
+	for _, a := range answers {
+		path := w.dayPath(a.Input)
+		if len(w.explicit) > 0 && !slices.Contains(w.explicit, path) {
+			continue
+		}
+		in := add(path)
+		in.want[a.Part] = a.Answer
+		for name, value := range a.Params {
+			in.params[name] = value
+		}
+	}
+	if puzzle := w.dayPath(fmt.Sprintf("%d.txt", w.day.Day)); len(w.explicit) == 0 {
+		if _, err := os.Stat(puzzle); err == nil {
+			add(puzzle)
+		}
+	}
+	for i := range inputs {
+		for name, value := range w.params {
+			inputs[i].params[name] = value
+		}
+	}
+	return inputs, nil
+}
+
+// changed returns the watched files that appeared, changed or vanished
+// since the last call, inputs and the answers file first, then sources
+func (w *watcher) changed(inputs []watchedInput) (data, sources []string) {
+	files := []string{w.dayPath(aoc.AnswersPath(w.day.Day))}
+	for _, in := range inputs {
+		files = append(files, in.path)
+	}
+	goFiles, _ := filepath.Glob(w.dayPath("*.go"))
+	stamps := make(map[string]stamp)
+	for i, path := range slices.Concat(files, goFiles) {
+		var st stamp
+		if info, err := os.Stat(path); err == nil {
+			st = stamp{size: info.Size(), modTime: info.ModTime()}
+		}
+		if st != w.stamps[path] {
+			if i < len(files) {
+				data = append(data, path)
+			} else {
+				sources = append(sources, path)
+			}
+		}
+		stamps[path] = st
+	}
+	// sources that vanished
+	for path := range w.stamps {
+		if _, still := stamps[path]; !still && strings.HasSuffix(path, ".go") {
+			sources = append(sources, path)
+		}
+	}
+	w.stamps = stamps
+	return data, sources
+}
+
+// check solves the inputs if any of them or the answers file changed since
+// the last check, or this is the first, writing how it went to out
+func (w *watcher) check(ctx context.Context, out io.Writer) {
+	inputs, err := w.inputs()
+	first := len(w.stamps) == 0
+	data, sources := w.changed(inputs)
+	if !first && len(data) == 0 && len(sources) == 0 {
+		return
+	}
+
+	now := time.Now().Format("15:04:05")
+	switch {
+	case first:
+		fmt.Fprintf(out, "-- %s  solving day %d\n", now, w.day.Day)
+	case len(data) > 0:
+		fmt.Fprintf(out, "-- %s  %s changed\n", now, strings.Join(data, ", "))
+	}
+	if len(sources) > 0 && !first {
+		slices.Sort(sources)
+		fmt.Fprintf(out, "-- %s  %s changed: rebuild aoc2024 to solve with it\n", now, strings.Join(sources, ", "))
+	}
+	if len(data) == 0 && !first {
+		return
+	}
+
+	if err != nil {
+		fmt.Fprintf(out, "error: %s\n", err.Error())
+		return
+	}
+	if len(inputs) == 0 {
+		fmt.Fprintf(out, "no inputs: add examples to %s, or %s\n",
+			w.dayPath(aoc.AnswersPath(w.day.Day)), w.dayPath(fmt.Sprintf("%d.txt", w.day.Day)))
+		return
+	}
+	for _, in := range inputs {
+		fmt.Fprintln(out, in.path)
+		data, err := os.ReadFile(in.path)
+		if err != nil {
+			fmt.Fprintf(out, "  error: %s\n", err.Error())
+			continue
+		}
+		for part := 1; part <= 2; part++ {
+			if !w.day.HasPart(part) {
+				continue
+			}
+			solveCtx, cancel := w.solveContext(aoc.WithParams(ctx, in.params))
+			r := w.day.Run(solveCtx, string(data), part)[0]
+			cancel()
+			fmt.Fprintln(out, w.compare(in, r))
+		}
+	}
+}
+
+// solveContext limits solving a part to the timeout, if it is set
+func (w *watcher) solveContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if w.timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, w.timeout)
+}
+
+// compare formats a part's result against the last run's and the expected
+// answer, and remembers it for the next run
+func (w *watcher) compare(in watchedInput, r aoc.Result) string {
+	answer := fmt.Sprint(r.Answer)
+	if r.Err != nil {
+		answer = "error: " + r.Err.Error()
+	}
+	var notes []string
+	if want, ok := in.want[r.Part]; ok && r.Err == nil && answer == want {
+		notes = append(notes, "ok")
+	} else if ok {
+		notes = append(notes, "want "+want)
+	}
+	key := fmt.Sprintf("%s %d", in.path, r.Part)
+	if was, ok := w.previous[key]; ok && was != answer {
+		notes = append(notes, "was "+was)
+	}
+	w.previous[key] = answer
+
+	line := fmt.Sprintf("  part %d  %-20s %8s", r.Part, answer, r.Elapsed.Round(time.Microsecond))
+	if len(notes) > 0 {
+		line += "  " + strings.Join(notes, ", ")
+	}
+	return line
+}
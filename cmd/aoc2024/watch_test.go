@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/aoc"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "1"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	example := filepath.Join(dir, "1", "1.test.txt")
+	write := func(path, data string, age time.Duration) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+		// a write within the file system's timestamp granularity still shows
+		modTime := time.Now().Add(-age)
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(example, "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n", time.Hour)
+	write(filepath.Join(dir, "1", "1.answers.txt"), "1.test.txt  1  11\n1.test.txt  2  31\n", time.Hour)
+
+	d, _ := aoc.Lookup(1)
+	w := newWatcher(dir, d, nil, nil, 0)
+	check := func() string {
+		var out bytes.Buffer
+		w.check(context.Background(), &out)
+		return out.String()
+	}
+
+	got := check()
+	for _, want := range []string{"solving day 1", example, "part 1  11 ", "part 2  31 ", "ok"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("first check: %q missing from\n%s", want, got)
+		}
+	}
+	if got := check(); got != "" {
+		t.Errorf("check with nothing changed: got\n%s", got)
+	}
+
+	// a changed example shows the answers it changed, against the last run
+	// and the expected ones
+	write(example, "3   4\n4   3\n2   5\n1   3\n3   9\n3   4\n", 0)
+	got = check()
+	for _, want := range []string{example + " changed", "part 1  12 ", "want 11, was 11", "part 2  26 ", "want 31, was 31"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("after a change: %q missing from\n%s", want, got)
+		}
+	}
+
+	// a source change can't be solved with until a rebuild
+	write(filepath.Join(dir, "1", "day1.go"), "package day1\n", 0)
+	if got := check(); !strings.Contains(got, "day1.go changed: rebuild") || strings.Contains(got, "part 1") {
+		t.Errorf("after a source change: got\n%s", got)
+	}
+
+	// the real puzzle input is solved too once it appears, and a broken
+	// answers file is reported
+	write(filepath.Join(dir, "1", "1.txt"), "1   1\n", 0)
+	if got := check(); !strings.Contains(got, filepath.Join(dir, "1", "1.txt")+"\n  part 1  0 ") {
+		t.Errorf("after adding 1.txt: got\n%s", got)
+	}
+	write(filepath.Join(dir, "1", "1.answers.txt"), "1.test.txt  3  11\n", 0)
+	if got := check(); !strings.Contains(got, "error: ") || !strings.Contains(got, "bad part") {
+		t.Errorf("after breaking the answers: got\n%s", got)
+	}
+}
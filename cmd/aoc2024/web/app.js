@@ -0,0 +1,253 @@
+// The aoc2024 dashboard: solves a day's puzzle through the serve API, then
+// draws the grids its solver snapshots, and replays its simulation.
+"use strict";
+
+const $ = (id) => document.getElementById(id);
+
+let days = [];     // from GET /days
+let current;       // the day shown
+let frames = [];   // of the replay
+let colors = {};   // of the replay, by glyph
+let timer;         // playing the replay
+
+// cellSize is the pixels per cell that fit a grid in about maxPixels
+function cellSize(width, height, maxPixels = 640) {
+  return Math.max(1, Math.min(12, Math.floor(maxPixels / Math.max(width, height, 1))));
+}
+
+// draw paints rows of glyphs on canvas, coloured by glyph
+function draw(canvas, rows, palette) {
+  const height = rows.length;
+  const width = height ? rows[0].length : 0;
+  const size = cellSize(width, height);
+  if (canvas.width !== width * size || canvas.height !== height * size) {
+    canvas.width = width * size;
+    canvas.height = height * size;
+  }
+  const ctx = canvas.getContext("2d");
+  ctx.fillStyle = palette[" "] || "#1e1e2e";
+  ctx.fillRect(0, 0, canvas.width, canvas.height);
+  rows.forEach((row, y) => {
+    for (let x = 0; x < row.length; x++) {
+      const color = palette[row[x]];
+      if (color && color !== palette[" "]) {
+        ctx.fillStyle = color;
+        ctx.fillRect(x * size, y * size, size, size);
+      }
+    }
+  });
+}
+
+function formatNs(ns) {
+  if (ns >= 1e9) return (ns / 1e9).toFixed(2) + "s";
+  if (ns >= 1e6) return (ns / 1e6).toFixed(2) + "ms";
+  if (ns >= 1e3) return (ns / 1e3).toFixed(1) + "µs";
+  return ns + "ns";
+}
+
+function showError(message) {
+  $("error").textContent = message;
+  $("error").hidden = !message;
+}
+
+// api calls the serve API, returning its JSON, or throwing its error
+async function api(method, path, body) {
+  const resp = await fetch(path, { method, body });
+  const data = await resp.json();
+  if (!resp.ok && !("answer" in data) && !("grids" in data)) {
+    throw new Error(data.error || resp.statusText);
+  }
+  return data;
+}
+
+// query is the day's puzzle parameters, less those left at their defaults,
+// added to q
+function query(q = new URLSearchParams()) {
+  for (const input of $("params").querySelectorAll("input")) {
+    if (input.value !== input.placeholder) {
+      q.set(input.name, input.value);
+    }
+  }
+  const s = q.toString();
+  return s ? "?" + s : "";
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+function showDays() {
+  const list = $("days");
+  list.replaceChildren();
+  for (const d of days) {
+    const a = document.createElement("a");
+    a.href = "#" + d.day;
+    a.textContent = "Day " + d.day;
+    const li = document.createElement("li");
+    li.append(a);
+    if (d.replay) {
+      const tag = document.createElement("span");
+      tag.className = "tag";
+      tag.textContent = " ▶";
+      li.append(tag);
+    }
+    list.append(li);
+  }
+}
+
+function selectDay() {
+  const n = Number(location.hash.slice(1));
+  current = days.find((d) => d.day === n);
+  $("day").hidden = !current;
+  $("welcome").hidden = !!current;
+  for (const a of $("days").querySelectorAll("a")) {
+    a.classList.toggle("current", a.hash === location.hash);
+  }
+  stop();
+  $("results").tBodies[0].replaceChildren();
+  $("grids").replaceChildren();
+  $("player").hidden = true;
+  showError("");
+  if (!current) return;
+
+  $("title").textContent = "Day " + current.day;
+  $("replay").hidden = !current.replay;
+  const params = $("params");
+  params.replaceChildren();
+  for (const [name, value] of Object.entries(current.params || {}).sort()) {
+    const label = document.createElement("label");
+    label.textContent = name;
+    const input = document.createElement("input");
+    input.type = "text";
+    input.name = name;
+    input.value = value;
+    input.placeholder = value;
+    label.append(input);
+    params.append(label);
+  }
+}
+
+async function loadExample() {
+  showError("");
+  try {
+    const resp = await fetch(`/days/${current.day}/example`);
+    if (!resp.ok) throw new Error((await resp.json()).error);
+    $("input").value = await resp.text();
+  } catch (err) {
+    showError(err.message);
+  }
+}
+
+async function solve() {
+  const day = current;
+  const body = $("input").value;
+  const rows = $("results").tBodies[0];
+  rows.replaceChildren();
+  $("grids").replaceChildren();
+  showError("");
+  $("solve").disabled = true;
+  try {
+    for (const part of day.parts) {
+      const result = await api("POST", `/days/${day.day}/parts/${part}/grids${query()}`, body);
+      if (day !== current) return;
+      const tr = rows.insertRow();
+      tr.insertCell().textContent = part;
+      const answer = tr.insertCell();
+      if (result.error) {
+        answer.textContent = result.error;
+        answer.className = "error";
+      } else {
+        answer.textContent = result.answer;
+      }
+      tr.insertCell().textContent = formatNs(result.elapsed_ns);
+      for (const g of result.grids) {
+        const figure = document.createElement("figure");
+        const caption = document.createElement("figcaption");
+        caption.textContent = `${part}: ${g.name}`;
+        const canvas = document.createElement("canvas");
+        draw(canvas, g.rows, result.colors);
+        figure.append(caption, canvas);
+        $("grids").append(figure);
+      }
+    }
+  } catch (err) {
+    showError(err.message);
+  } finally {
+    $("solve").disabled = false;
+  }
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+async function record() {
+  const day = current;
+  stop();
+  showError("");
+  $("replay").disabled = true;
+  try {
+    const rec = await api("POST", `/days/${day.day}/replay${query(new URLSearchParams({ frames: 1000 }))}`, $("input").value);
+    if (day !== current) return;
+    frames = rec.frames;
+    colors = rec.colors;
+    $("frame").max = Math.max(frames.length - 1, 0);
+    $("player").hidden = frames.length === 0;
+    show(0);
+  } catch (err) {
+    showError(err.message);
+  } finally {
+    $("replay").disabled = false;
+  }
+}
+
+function show(i) {
+  const f = frames[i];
+  if (!f) return;
+  $("frame").value = i;
+  $("caption").textContent = `step ${f.step}` + (f.caption ? `: ${f.caption}` : "");
+  draw($("screen"), f.rows, colors);
+}
+
+function play() {
+  if (timer) {
+    stop();
+    return;
+  }
+  let i = Number($("frame").value);
+  if (i >= frames.length - 1) i = 0;
+  $("play").textContent = "pause";
+  const tick = () => {
+    show(i);
+    if (++i >= frames.length) {
+      stop();
+      return;
+    }
+    timer = setTimeout(tick, 1000 / Number($("fps").value));
+  };
+  tick();
+}
+
+function stop() {
+  clearTimeout(timer);
+  timer = undefined;
+  $("play").textContent = "play";
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+$("example").onclick = loadExample;
+$("solve").onclick = solve;
+$("replay").onclick = record;
+$("play").onclick = play;
+$("frame").oninput = () => {
+  stop();
+  show(Number($("frame").value));
+};
+window.onhashchange = selectDay;
+
+(async () => {
+  try {
+    days = await api("GET", "/days");
+    showDays();
+    selectDay();
+  } catch (err) {
+    showError(err.message);
+  }
+})();
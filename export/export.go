@@ -0,0 +1,234 @@
+// Package export draws byte grids as images: PNG or SVG pictures of a
+// single state, and animated GIFs of a recorded simulation.
+//
+// Cells are coloured by glyph through a Palette, so any day's grid, drawn
+// with the same glyphs it prints, can be exported.
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"time"
+
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+// Palette maps glyphs to colours.
+type Palette struct {
+	Colors     map[byte]color.RGBA
+	Background color.RGBA // for ' ' and unlisted glyphs that aren't letters or digits
+}
+
+// Color returns the colour of a glyph.  Letters and digits missing from
+// Colors get a stable colour of their own, so regions and IDs stand apart.
+func (p Palette) Color(glyph byte) color.RGBA {
+	if c, ok := p.Colors[glyph]; ok {
+		return c
+	}
+	if isAlnum(glyph) {
+		return hashColor(glyph)
+	}
+	return p.Background
+}
+
+func isAlnum(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// hashColor picks a bright colour for glyph from its hash
+func hashColor(glyph byte) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte{glyph, glyph * 31})
+	sum := h.Sum32()
+	return color.RGBA{R: 64 + byte(sum)%192, G: 64 + byte(sum>>8)%192, B: 64 + byte(sum>>16)%192, A: 255}
+}
+
+func rgb(hex uint32) color.RGBA {
+	return color.RGBA{R: byte(hex >> 16), G: byte(hex >> 8), B: byte(hex), A: 255}
+}
+
+// DefaultPalette colours the glyphs the days draw with: floors, walls,
+// guards and their paths, robots, boxes, and digits as heat.
+var DefaultPalette = Palette{
+	Background: rgb(0x1e1e2e),
+	Colors: map[byte]color.RGBA{
+		'.': rgb(0x313244), // floor, free space
+		'#': rgb(0x9399b2), // walls, obstacles, antinodes
+		'O': rgb(0xfab387), // boxes, obstructions
+		'[': rgb(0xfab387),
+		']': rgb(0xfab387),
+		'@': rgb(0xa6e3a1), // robots
+		'^': rgb(0xf38ba8), // guards
+		'v': rgb(0xf38ba8),
+		'<': rgb(0xf38ba8),
+		'>': rgb(0xf38ba8),
+		'-': rgb(0xf9e2af), // guard paths
+		'|': rgb(0xf9e2af),
+		'+': rgb(0xf9e2af),
+		'X': rgb(0xf9e2af),
+		'1': rgb(0x45475a), // counts, as heat
+		'2': rgb(0x6c4a5a),
+		'3': rgb(0x924d5a),
+		'4': rgb(0xb8505a),
+		'5': rgb(0xde535a),
+		'6': rgb(0xf0705a),
+		'7': rgb(0xf5955a),
+		'8': rgb(0xfaba5a),
+		'9': rgb(0xffdf5a),
+	},
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// colorIndex assigns each glyph used by some grids an index in a palette
+type colorIndex struct {
+	palette color.Palette
+	index   [256]uint8
+}
+
+func newColorIndex(p Palette, grids ...*grid.Grid[byte]) *colorIndex {
+	var used [256]bool
+	for _, g := range grids {
+		for _, c := range g.All() {
+			used[c] = true
+		}
+	}
+	ci := &colorIndex{palette: color.Palette{p.Background}}
+	for glyph, isUsed := range used {
+		if !isUsed {
+			continue
+		}
+		c := p.Color(byte(glyph))
+		i := ci.palette.Index(c)
+		if ci.palette[i] != color.Color(c) && len(ci.palette) < 256 {
+			i = len(ci.palette)
+			ci.palette = append(ci.palette, c)
+		}
+		ci.index[glyph] = uint8(i)
+	}
+	return ci
+}
+
+// paint draws the cells of g within rect, in cell units, scale pixels each
+func (ci *colorIndex) paint(g *grid.Grid[byte], rect image.Rectangle, scale int) *image.Paletted {
+	img := image.NewPaletted(image.Rect(rect.Min.X*scale, rect.Min.Y*scale, rect.Max.X*scale, rect.Max.Y*scale), ci.palette)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			i := ci.index[g.At(grid.Point{X: x, Y: y})]
+			for py := y * scale; py < (y+1)*scale; py++ {
+				row := img.Pix[img.PixOffset(x*scale, py):]
+				for px := 0; px < scale; px++ {
+					row[px] = i
+				}
+			}
+		}
+	}
+	return img
+}
+
+func cells(g *grid.Grid[byte]) image.Rectangle {
+	return image.Rect(0, 0, g.Width(), g.Height())
+}
+
+// Image draws g with scale pixels per cell.
+func Image(g *grid.Grid[byte], p Palette, scale int) *image.Paletted {
+	return newColorIndex(p, g).paint(g, cells(g), max(scale, 1))
+}
+
+// PNG writes g as a PNG image, with scale pixels per cell.
+func PNG(w io.Writer, g *grid.Grid[byte], p Palette, scale int) error {
+	return png.Encode(w, Image(g, p, scale))
+}
+
+// SVG writes g as an SVG image, with scale units per cell.
+// Runs of a glyph along a row are drawn as one rectangle.
+func SVG(w io.Writer, g *grid.Grid[byte], p Palette, scale int) error {
+	scale = max(scale, 1)
+	bw := bufio.NewWriter(w)
+	width, height := g.Width()*scale, g.Height()*scale
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`+"\n",
+		width, height, width, height)
+	fmt.Fprintf(bw, `<rect width="%d" height="%d" fill="%s"/>`+"\n", width, height, hexColor(p.Background))
+	for y := 0; y < g.Height(); y++ {
+		row := g.Row(y)
+		for x := 0; x < len(row); {
+			run := 1
+			for x+run < len(row) && row[x+run] == row[x] {
+				run++
+			}
+			if c := p.Color(row[x]); c != p.Background {
+				fmt.Fprintf(bw, `<rect x="%d" y="%d" width="%d" height="%d" fill="%s"/>`+"\n",
+					x*scale, y*scale, run*scale, scale, hexColor(c))
+			}
+			x += run
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// GIF writes frames as an animated GIF, showing each for delay, with scale
+// pixels per cell.  After the first, each frame only redraws the cells that
+// changed, so long simulations stay small.
+func GIF(w io.Writer, frames []replay.Frame, p Palette, scale int, delay time.Duration) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to export")
+	}
+	scale = max(scale, 1)
+	grids := make([]*grid.Grid[byte], len(frames))
+	for i, f := range frames {
+		grids[i] = f.Grid
+	}
+	ci := newColorIndex(p, grids...)
+	first := grids[0]
+	anim := &gif.GIF{
+		Config: image.Config{
+			ColorModel: ci.palette,
+			Width:      first.Width() * scale,
+			Height:     first.Height() * scale,
+		},
+	}
+	hundredths := max(int(delay/(10*time.Millisecond)), 1)
+	for i, g := range grids {
+		if g.Extent() != first.Extent() {
+			return fmt.Errorf("frame %d is %v, unlike the first frame's %v", i, g.Extent(), first.Extent())
+		}
+		rect := cells(g)
+		if i > 0 {
+			rect = changed(grids[i-1], g)
+		}
+		anim.Image = append(anim.Image, ci.paint(g, rect, scale))
+		anim.Delay = append(anim.Delay, hundredths)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+	}
+	anim.Delay[len(anim.Delay)-1] = max(hundredths, 200) // linger on the outcome
+	return gif.EncodeAll(w, anim)
+}
+
+// changed returns the bounds of the cells that differ between grids of the
+// same size, or a single cell if none do
+func changed(prev, g *grid.Grid[byte]) image.Rectangle {
+	var r image.Rectangle
+	for pt, c := range g.All() {
+		if prev.At(pt) != c {
+			r = r.Union(image.Rect(pt.X, pt.Y, pt.X+1, pt.Y+1))
+		}
+	}
+	if r.Empty() {
+		r = image.Rect(0, 0, 1, 1)
+	}
+	return r
+}
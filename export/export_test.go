@@ -0,0 +1,102 @@
+package export
+
+import (
+	"bytes"
+	"image/gif"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+func mustParse(t *testing.T, text string) *grid.Grid[byte] {
+	t.Helper()
+	g, err := grid.Parse(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestPNG(t *testing.T) {
+	g := mustParse(t, "#..\n.@a\n")
+	var buf bytes.Buffer
+	if err := PNG(&buf, g, DefaultPalette, 4); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := img.Bounds(); b.Dx() != 12 || b.Dy() != 8 {
+		t.Fatalf("image is %v, want 12x8", b)
+	}
+	for pt, glyph := range g.All() {
+		want := DefaultPalette.Color(glyph)
+		r, gr, b, _ := img.At(pt.X*4+3, pt.Y*4+3).RGBA()
+		if byte(r>>8) != want.R || byte(gr>>8) != want.G || byte(b>>8) != want.B {
+			t.Errorf("cell %v %q is %v, want %v", pt, glyph, img.At(pt.X*4+3, pt.Y*4+3), want)
+		}
+	}
+	if DefaultPalette.Color('a') == DefaultPalette.Color('b') {
+		t.Error("letters should get their own colours")
+	}
+}
+
+func TestSVG(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SVG(&buf, mustParse(t, "###.\n    \n"), DefaultPalette, 10); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="40" height="20"`) {
+		t.Errorf("bad header:\n%s", svg)
+	}
+	// the background, a run of walls, and a floor; blanks are background
+	if got := strings.Count(svg, "<rect"); got != 3 {
+		t.Errorf("got %d rects, want 3:\n%s", got, svg)
+	}
+	if !strings.Contains(svg, `<rect x="0" y="0" width="30" height="10" fill="#9399b2"/>`) {
+		t.Errorf("walls not drawn as one run:\n%s", svg)
+	}
+}
+
+func TestGIF(t *testing.T) {
+	var frames []replay.Frame
+	for i := 0; i < 4; i++ {
+		g := mustParse(t, "....\n....\n....\n")
+		g.Set(grid.Point{X: i, Y: 1}, '@')
+		frames = append(frames, replay.Frame{Step: i, Grid: g})
+	}
+	frames = append(frames, frames[3]) // no change
+
+	var buf bytes.Buffer
+	if err := GIF(&buf, frames, DefaultPalette, 2, 50*time.Millisecond); err != nil {
+		t.Fatal(err)
+	}
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 5 || anim.Config.Width != 8 || anim.Config.Height != 6 {
+		t.Fatalf("got %d frames of %dx%d, want 5 of 8x6", len(anim.Image), anim.Config.Width, anim.Config.Height)
+	}
+	if anim.Delay[0] != 5 {
+		t.Errorf("delay %d, want 5 hundredths", anim.Delay[0])
+	}
+	// later frames only redraw the robot's old and new cells
+	if b := anim.Image[1].Bounds(); b.Dx() != 4 || b.Dy() != 2 {
+		t.Errorf("frame 1 redraws %v, want the 2 changed cells", b)
+	}
+	if b := anim.Image[4].Bounds(); b.Dx() != 2 || b.Dy() != 2 {
+		t.Errorf("unchanged frame redraws %v, want a single cell", b)
+	}
+
+	frames[2].Grid = grid.New[byte](2, 2)
+	if err := GIF(&buf, frames, DefaultPalette, 2, time.Second); err == nil {
+		t.Error("frames of different sizes should fail")
+	}
+}
@@ -0,0 +1,156 @@
+// Package graph searches graphs given by a function listing each node's
+// neighbours, so the same searches run over grid cells, puzzle states or
+// adjacency lists: breadth- and depth-first traversal, reachability,
+// connected components, shortest paths by BFS, Dijkstra and A*, and
+// counting paths.
+//
+// Nodes are any comparable type, e.g. a grid.Point, or a struct of a point
+// and a facing for a walker whose moves depend on its direction.
+package graph
+
+import (
+	"iter"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// Neighbors lists the nodes one step from n.
+type Neighbors[N comparable] func(n N) iter.Seq[N]
+
+// Adjacency returns the neighbours of an adjacency list.
+func Adjacency[N comparable](adj map[N][]N) Neighbors[N] {
+	return func(n N) iter.Seq[N] {
+		return func(yield func(N) bool) {
+			for _, m := range adj[n] {
+				if !yield(m) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Grid4 returns the orthogonal neighbours of points in g that can be
+// stepped to, as reported by ok.
+func Grid4[T any](g *grid.Grid[T], ok func(from, to grid.Point) bool) Neighbors[grid.Point] {
+	return func(p grid.Point) iter.Seq[grid.Point] {
+		return func(yield func(grid.Point) bool) {
+			for n := range g.Neighbors4(p) {
+				if ok(p, n) && !yield(n) {
+					return
+				}
+			}
+		}
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// BFS iterates breadth-first over the nodes reachable from starts, each once,
+// with its distance in steps from the nearest start.
+func BFS[N comparable](next Neighbors[N], starts ...N) iter.Seq2[N, int] {
+	return func(yield func(N, int) bool) {
+		dist := make(map[N]int)
+		var queue []N
+		for _, s := range starts {
+			if _, seen := dist[s]; !seen {
+				dist[s] = 0
+				queue = append(queue, s)
+			}
+		}
+		for len(queue) > 0 {
+			n := queue[0]
+			queue = queue[1:]
+			if !yield(n, dist[n]) {
+				return
+			}
+			for m := range next(n) {
+				if _, seen := dist[m]; !seen {
+					dist[m] = dist[n] + 1
+					queue = append(queue, m)
+				}
+			}
+		}
+	}
+}
+
+// Set is the nodes a search has seen.
+type Set[N comparable] interface {
+	// Add adds n, reporting whether it was new.
+	Add(n N) bool
+}
+
+// mapSet is the Set searches use unless given one
+type mapSet[N comparable] map[N]bool
+
+func (s mapSet[N]) Add(n N) bool {
+	if s[n] {
+		return false
+	}
+	s[n] = true
+	return true
+}
+
+// DFS iterates depth-first over the nodes reachable from starts, each once,
+// in the order they are first reached.
+func DFS[N comparable](next Neighbors[N], starts ...N) iter.Seq[N] {
+	return DFSWith(next, make(mapSet[N]), starts...)
+}
+
+// DFSWith is DFS keeping track of the nodes seen in seen, which can be
+// faster than a map, e.g. flags on a grid, or record something as it goes.
+func DFSWith[N comparable](next Neighbors[N], seen Set[N], starts ...N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		var stack []N
+		for i := len(starts) - 1; i >= 0; i-- {
+			stack = append(stack, starts[i])
+		}
+		var children []N
+		for len(stack) > 0 {
+			n := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if !seen.Add(n) {
+				continue
+			}
+			if !yield(n) {
+				return
+			}
+			// push in reverse, so the first neighbour is explored first
+			children = children[:0]
+			for m := range next(n) {
+				children = append(children, m)
+			}
+			for i := len(children) - 1; i >= 0; i-- {
+				stack = append(stack, children[i])
+			}
+		}
+	}
+}
+
+// Reachable returns the set of nodes reachable from starts, starts included.
+func Reachable[N comparable](next Neighbors[N], starts ...N) map[N]bool {
+	reached := make(map[N]bool)
+	for n := range DFS(next, starts...) {
+		reached[n] = true
+	}
+	return reached
+}
+
+// Components partitions nodes into connected components, each in the order
+// DFS reaches it, in the order of their first nodes.  next must be
+// symmetric, as for an undirected graph, and stay within nodes.
+func Components[N comparable](nodes iter.Seq[N], next Neighbors[N]) [][]N {
+	var components [][]N
+	seen := make(mapSet[N])
+	for n := range nodes {
+		if seen[n] {
+			continue
+		}
+		var component []N
+		for m := range DFSWith(next, seen, n) {
+			component = append(component, m)
+		}
+		components = append(components, component)
+	}
+	return components
+}
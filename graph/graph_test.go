@@ -0,0 +1,152 @@
+package graph
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/memo"
+)
+
+// maze is open '.' and walls '#', with a costly digit on the shortest way
+const maze = `
+S.9.E
+.###.
+.....`
+
+func parseMaze(t *testing.T) (*grid.Grid[byte], grid.Point, grid.Point) {
+	t.Helper()
+	g, err := grid.Parse(maze[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	start, _ := g.Find(func(c byte) bool { return c == 'S' })
+	end, _ := g.Find(func(c byte) bool { return c == 'E' })
+	return g, start, end
+}
+
+func open(g *grid.Grid[byte]) Neighbors[grid.Point] {
+	return Grid4(g, func(from, to grid.Point) bool { return g.At(to) != '#' })
+}
+
+func TestBFS(t *testing.T) {
+	g, start, end := parseMaze(t)
+	dist := make(map[grid.Point]int)
+	last := 0
+	for n, d := range BFS(open(g), start) {
+		if d < last {
+			t.Errorf("%v at distance %d after distance %d", n, d, last)
+		}
+		dist[n], last = d, d
+	}
+	if dist[end] != 4 || dist[grid.Point{X: 2, Y: 2}] != 4 || len(dist) != 12 {
+		t.Errorf("distances %v, want 4 to the end and (2,2), of 12 cells", dist)
+	}
+
+	path, ok := ShortestPath(open(g), start, func(p grid.Point) bool { return p == end })
+	if !ok || len(path) != 5 || path[0] != start || path[4] != end {
+		t.Errorf("ShortestPath = %v, %v", path, ok)
+	}
+	if _, ok := ShortestPath(open(g), start, func(grid.Point) bool { return false }); ok {
+		t.Error("ShortestPath to nowhere should fail")
+	}
+}
+
+func TestDFS(t *testing.T) {
+	next := Adjacency(map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"c": {"d", "a"},
+		"e": {"a"},
+	})
+	if got := slices.Collect(DFS(next, "a")); !slices.Equal(got, []string{"a", "b", "d", "c"}) {
+		t.Errorf("DFS from a = %v", got)
+	}
+	if got := Reachable(next, "c"); len(got) != 4 || got["e"] {
+		t.Errorf("Reachable from c = %v", got)
+	}
+	for n := range DFS(next, "a") {
+		if n == "b" {
+			break // stopping early must not panic
+		}
+	}
+}
+
+func TestComponents(t *testing.T) {
+	g, err := grid.Parse("AAB\nABB\nCCA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	same := Grid4(g, func(from, to grid.Point) bool { return g.At(from) == g.At(to) })
+	var sizes []int
+	for _, c := range Components(g.Points(), same) {
+		sizes = append(sizes, len(c))
+	}
+	if !slices.Equal(sizes, []int{3, 3, 2, 1}) {
+		t.Errorf("component sizes %v, want [3 3 2 1]", sizes)
+	}
+}
+
+func TestDijkstra(t *testing.T) {
+	g, start, end := parseMaze(t)
+	// digits cost that much to step onto, other cells 1
+	edges := func(p grid.Point) iter.Seq2[grid.Point, int] {
+		return func(yield func(grid.Point, int) bool) {
+			for n := range open(g)(p) {
+				cost := 1
+				if c := g.At(n); c >= '0' && c <= '9' {
+					cost = int(c - '0')
+				}
+				if !yield(n, cost) {
+					return
+				}
+			}
+		}
+	}
+	isEnd := func(p grid.Point) bool { return p == end }
+
+	path, cost, ok := Dijkstra(edges, start, isEnd)
+	if !ok || cost != 8 || len(path) != 9 || path[len(path)-1] != end {
+		t.Errorf("Dijkstra = %v, %d, %v; want cost 8 around the 9", path, cost, ok)
+	}
+	manhattan := func(p grid.Point) int {
+		d := end.Sub(p)
+		return max(d.X, -d.X) + max(d.Y, -d.Y)
+	}
+	if _, cost, ok := AStar(edges, start, isEnd, manhattan); !ok || cost != 8 {
+		t.Errorf("AStar cost = %d, %v; want 8", cost, ok)
+	}
+	if _, cost, _ := Dijkstra(Unweighted(open(g)), start, isEnd); cost != 4 {
+		t.Errorf("unweighted Dijkstra cost = %d, want 4", cost)
+	}
+}
+
+func TestCountPaths(t *testing.T) {
+	// a 4x4 grid moving only right or down has C(6,3) paths corner to corner
+	g := grid.New[byte](4, 4)
+	rightDown := Grid4(g, func(from, to grid.Point) bool { return to.X >= from.X && to.Y >= from.Y })
+	corner := grid.Point{X: 3, Y: 3}
+	if got := CountPaths(rightDown, grid.Point{}, func(p grid.Point) bool { return p == corner }); got != 20 {
+		t.Errorf("CountPaths = %d, want 20", got)
+	}
+
+	// from the middle, a shared memo already knows the way
+	counts := memo.New[grid.Point, int](0)
+	CountPathsWith(rightDown, counts, grid.Point{}, func(p grid.Point) bool { return p == corner })
+	misses := counts.Stats().Misses
+	if got := CountPathsWith(rightDown, counts, grid.Point{X: 1, Y: 1}, func(p grid.Point) bool { return p == corner }); got != 6 {
+		t.Errorf("CountPathsWith from (1,1) = %d, want 6", got)
+	}
+	if s := counts.Stats(); s.Misses != misses || s.Hits == 0 {
+		t.Errorf("CountPathsWith stats %v, want hits and no new misses", s)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("CountPaths on a cycle should panic")
+		}
+	}()
+	cycle := Adjacency(map[int][]int{1: {2}, 2: {1}})
+	CountPaths(cycle, 1, func(n int) bool { return n == 3 })
+}
@@ -0,0 +1,153 @@
+package graph
+
+import (
+	"container/heap"
+	"iter"
+	"slices"
+
+	"github.com/neomantra/aoc2024/memo"
+)
+
+// ShortestPath returns a path with the fewest steps from start to a node
+// that goal accepts, start and goal included, or false if none is reachable.
+func ShortestPath[N comparable](next Neighbors[N], start N, goal func(N) bool) ([]N, bool) {
+	prev := map[N]N{start: start}
+	queue := []N{start}
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if goal(n) {
+			return unwind(prev, start, n), true
+		}
+		for m := range next(n) {
+			if _, seen := prev[m]; !seen {
+				prev[m] = n
+				queue = append(queue, m)
+			}
+		}
+	}
+	return nil, false
+}
+
+// unwind follows prev back from end to start, returning the path forwards
+func unwind[N comparable](prev map[N]N, start, end N) []N {
+	path := []N{end}
+	for n := end; n != start; {
+		n = prev[n]
+		path = append(path, n)
+	}
+	slices.Reverse(path)
+	return path
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Edges lists the nodes one step from n, each with the step's cost, which
+// must not be negative.
+type Edges[N comparable] func(n N) iter.Seq2[N, int]
+
+// Unweighted returns next's steps, each costing 1.
+func Unweighted[N comparable](next Neighbors[N]) Edges[N] {
+	return func(n N) iter.Seq2[N, int] {
+		return func(yield func(N, int) bool) {
+			for m := range next(n) {
+				if !yield(m, 1) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Dijkstra returns a cheapest path from start to a node that goal accepts,
+// start and goal included, and its cost, or false if none is reachable.
+func Dijkstra[N comparable](edges Edges[N], start N, goal func(N) bool) ([]N, int, bool) {
+	return AStar(edges, start, goal, func(N) int { return 0 })
+}
+
+// AStar is Dijkstra guided by estimate, a lower bound on the cost from a
+// node to the nearest goal, e.g. the Manhattan distance on a grid.  An
+// estimate that is too high may give a path that is not the cheapest.
+func AStar[N comparable](edges Edges[N], start N, goal func(N) bool, estimate func(N) int) ([]N, int, bool) {
+	cost := map[N]int{start: 0}
+	prev := map[N]N{start: start}
+	done := make(map[N]bool)
+	open := &frontier[N]{{node: start, priority: estimate(start)}}
+	for open.Len() > 0 {
+		n := heap.Pop(open).(queued[N]).node
+		if done[n] {
+			continue // reached more cheaply already
+		}
+		done[n] = true
+		if goal(n) {
+			return unwind(prev, start, n), cost[n], true
+		}
+		for m, step := range edges(n) {
+			c := cost[n] + step
+			if old, seen := cost[m]; seen && old <= c {
+				continue
+			}
+			cost[m], prev[m] = c, n
+			heap.Push(open, queued[N]{node: m, priority: c + estimate(m)})
+		}
+	}
+	return nil, 0, false
+}
+
+// queued is a node waiting in a frontier
+type queued[N any] struct {
+	node     N
+	priority int
+}
+
+// frontier is a min-heap of queued nodes, by priority
+type frontier[N any] []queued[N]
+
+func (f frontier[N]) Len() int           { return len(f) }
+func (f frontier[N]) Less(i, j int) bool { return f[i].priority < f[j].priority }
+func (f frontier[N]) Swap(i, j int)      { f[i], f[j] = f[j], f[i] }
+func (f *frontier[N]) Push(x any)        { *f = append(*f, x.(queued[N])) }
+func (f *frontier[N]) Pop() any {
+	old := *f
+	q := old[len(old)-1]
+	*f = old[:len(old)-1]
+	return q
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// CountPaths returns how many distinct paths lead from start to nodes that
+// goal accepts, stopping at them.  Counts are memoised per node, so large
+// numbers of paths are cheap to count, but the graph must have no cycles
+// reachable from start: CountPaths panics on one.
+func CountPaths[N comparable](next Neighbors[N], start N, goal func(N) bool) int {
+	return CountPathsWith(next, memo.New[N, int](0), start, goal)
+}
+
+// CountPathsWith is CountPaths memoising the counts from each node in
+// counts, which searches with the same next and goal can share, e.g. from
+// each of several starts.
+func CountPathsWith[N comparable](next Neighbors[N], counts *memo.Cache[N, int], start N, goal func(N) bool) int {
+	onPath := make(map[N]bool)
+	var count func(n N) int
+	count = func(n N) int {
+		if goal(n) {
+			return 1
+		}
+		if c, ok := counts.Get(n); ok {
+			return c
+		}
+		if onPath[n] {
+			panic("graph: CountPaths found a cycle")
+		}
+		onPath[n] = true
+		total := 0
+		for m := range next(n) {
+			total += count(m)
+		}
+		delete(onPath, n)
+		counts.Put(n, total)
+		return total
+	}
+	return count(start)
+}
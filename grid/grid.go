@@ -0,0 +1,283 @@
+// Package grid is a generic 2D grid of cells, as found in many puzzles.
+//
+// Points are (X, Y) with X increasing to the right and Y increasing down,
+// matching how puzzle text is read line by line.
+package grid
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/neomantra/aoc2024/parse"
+)
+
+///////////////////////////////////////////////////////////////////////////////
+
+type Point struct{ X, Y int }
+
+func (p Point) Add(other Point) Point { return Point{p.X + other.X, p.Y + other.Y} }
+
+func (p Point) Sub(other Point) Point { return Point{p.X - other.X, p.Y - other.Y} }
+
+func (p Point) Mul(k int) Point { return Point{p.X * k, p.Y * k} }
+
+func (p Point) String() string { return fmt.Sprintf("(%d,%d)", p.X, p.Y) }
+
+///////////////////////////////////////////////////////////////////////////////
+// Directions are unit Points.
+
+var (
+	Up        = Point{0, -1}
+	Down      = Point{0, +1}
+	Left      = Point{-1, 0}
+	Right     = Point{+1, 0}
+	UpLeft    = Point{-1, -1}
+	UpRight   = Point{+1, -1}
+	DownLeft  = Point{-1, +1}
+	DownRight = Point{+1, +1}
+)
+
+// Dirs4 are the orthogonal directions, clockwise from Up.
+var Dirs4 = []Point{Up, Right, Down, Left}
+
+// Dirs8 are the orthogonal and diagonal directions, clockwise from Up.
+var Dirs8 = []Point{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+
+// RotateRight rotates a direction clockwise 90 degrees.
+func RotateRight(dir Point) Point { return Point{-dir.Y, dir.X} }
+
+// RotateLeft rotates a direction counter-clockwise 90 degrees.
+func RotateLeft(dir Point) Point { return Point{dir.Y, -dir.X} }
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Grid is a rectangular grid of cells of type T.
+type Grid[T any] struct {
+	cells         []T // row-major, [Y*width + X]
+	width, height int
+}
+
+// New returns a width x height grid of zero cells.
+func New[T any](width, height int) *Grid[T] {
+	if width < 0 || height < 0 {
+		panic(fmt.Sprintf("grid: bad size %dx%d", width, height))
+	}
+	return &Grid[T]{
+		cells:  make([]T, width*height),
+		width:  width,
+		height: height,
+	}
+}
+
+// NewLike returns an empty grid with the same size as other.
+func NewLike[T, U any](other *Grid[U]) *Grid[T] {
+	return New[T](other.width, other.height)
+}
+
+func (g *Grid[T]) Width() int    { return g.width }
+func (g *Grid[T]) Height() int   { return g.height }
+func (g *Grid[T]) Extent() Point { return Point{g.width, g.height} }
+
+func (g *Grid[T]) InBounds(p Point) bool {
+	return p.X >= 0 && p.X < g.width && p.Y >= 0 && p.Y < g.height
+}
+
+// At returns the cell at p, panicking if out-of-bounds.
+func (g *Grid[T]) At(p Point) T {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: %v out of bounds %dx%d", p, g.width, g.height))
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+// Get returns the cell at p, and false if out-of-bounds.
+func (g *Grid[T]) Get(p Point) (T, bool) {
+	if !g.InBounds(p) {
+		var zero T
+		return zero, false
+	}
+	return g.cells[p.Y*g.width+p.X], true
+}
+
+// GetOr returns the cell at p, or oob if out-of-bounds.
+func (g *Grid[T]) GetOr(p Point, oob T) T {
+	if !g.InBounds(p) {
+		return oob
+	}
+	return g.cells[p.Y*g.width+p.X]
+}
+
+// Set sets the cell at p, panicking if out-of-bounds.
+func (g *Grid[T]) Set(p Point, v T) {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: %v out of bounds %dx%d", p, g.width, g.height))
+	}
+	g.cells[p.Y*g.width+p.X] = v
+}
+
+// TrySet sets the cell at p, returning false if out-of-bounds.
+func (g *Grid[T]) TrySet(p Point, v T) bool {
+	if !g.InBounds(p) {
+		return false
+	}
+	g.cells[p.Y*g.width+p.X] = v
+	return true
+}
+
+// Fill sets every cell to v.
+func (g *Grid[T]) Fill(v T) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// Row returns row y.  It aliases the grid's storage.
+func (g *Grid[T]) Row(y int) []T {
+	return g.cells[y*g.width : (y+1)*g.width]
+}
+
+// Clone returns a deep copy of the grid.
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{
+		cells:  append([]T(nil), g.cells...),
+		width:  g.width,
+		height: g.height,
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Points iterates over every point, row by row.
+func (g *Grid[T]) Points() iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for y := 0; y < g.height; y++ {
+			for x := 0; x < g.width; x++ {
+				if !yield(Point{x, y}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// All iterates over every point and its cell, row by row.
+func (g *Grid[T]) All() iter.Seq2[Point, T] {
+	return func(yield func(Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(Point{i % g.width, i / g.width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// neighbors iterates over the in-bounds points at p+dir for each dir.
+func (g *Grid[T]) neighbors(p Point, dirs []Point) iter.Seq[Point] {
+	return func(yield func(Point) bool) {
+		for _, dir := range dirs {
+			n := p.Add(dir)
+			if g.InBounds(n) && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// Neighbors4 iterates over the in-bounds orthogonal neighbors of p.
+func (g *Grid[T]) Neighbors4(p Point) iter.Seq[Point] { return g.neighbors(p, Dirs4) }
+
+// Neighbors8 iterates over the in-bounds orthogonal and diagonal neighbors of p.
+func (g *Grid[T]) Neighbors8(p Point) iter.Seq[Point] { return g.neighbors(p, Dirs8) }
+
+// Find returns the first point whose cell matches, row by row.
+func (g *Grid[T]) Find(match func(T) bool) (Point, bool) {
+	for p, v := range g.All() {
+		if match(v) {
+			return p, true
+		}
+	}
+	return Point{}, false
+}
+
+// FindAll returns every point whose cell matches, row by row.
+func (g *Grid[T]) FindAll(match func(T) bool) []Point {
+	var found []Point
+	for p, v := range g.All() {
+		if match(v) {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// Count returns the number of cells that match.
+func (g *Grid[T]) Count(match func(T) bool) int {
+	count := 0
+	for _, v := range g.cells {
+		if match(v) {
+			count++
+		}
+	}
+	return count
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Parse reads a rectangular grid of bytes, one row per line.
+// A trailing newline is ignored.
+func Parse(text string) (*Grid[byte], error) {
+	return ParseFunc(text, func(c byte) (byte, error) { return c, nil })
+}
+
+// ParseFunc reads a rectangular grid, converting each byte with cell.
+// A trailing newline is ignored.  Errors are *parse.Error at the offending cell.
+func ParseFunc[T any](text string, cell func(byte) (T, error)) (*Grid[T], error) {
+	lines := parse.Lines(text)
+	if len(lines) == 0 {
+		return nil, parse.Errorf(1, "empty grid")
+	}
+	width := len(lines[0])
+	g := New[T](width, len(lines))
+	for y, line := range lines {
+		if len(line) != width {
+			return nil, parse.Errorf(y+1, "row is %d wide, expected %d", len(line), width)
+		}
+		for x := 0; x < width; x++ {
+			v, err := cell(line[x])
+			if err != nil {
+				return nil, &parse.Error{Line: y + 1, Col: x + 1, Err: err}
+			}
+			g.cells[y*width+x] = v
+		}
+	}
+	return g, nil
+}
+
+// ParseOnly reads a rectangular grid of bytes, allowing only the bytes in valid.
+func ParseOnly(text string, valid string) (*Grid[byte], error) {
+	return ParseFunc(text, func(c byte) (byte, error) {
+		if strings.IndexByte(valid, c) == -1 {
+			return 0, fmt.Errorf("unexpected %q", c)
+		}
+		return c, nil
+	})
+}
+
+// Render draws the grid as text, one row per line, using glyph for each cell.
+func (g *Grid[T]) Render(glyph func(T) byte) string {
+	var sb strings.Builder
+	sb.Grow((g.width + 1) * g.height)
+	for y := 0; y < g.height; y++ {
+		for _, v := range g.Row(y) {
+			sb.WriteByte(glyph(v))
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// Text draws a byte grid as text, one row per line.
+func Text(g *Grid[byte]) string {
+	return g.Render(func(c byte) byte { return c })
+}
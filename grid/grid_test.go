@@ -0,0 +1,156 @@
+package grid
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/neomantra/aoc2024/parse"
+)
+
+const sample = `
+#.a
+.b.
+a.#`
+
+func parseSample(t *testing.T) *Grid[byte] {
+	t.Helper()
+	g, err := Parse(sample[1:] + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func TestRotate(t *testing.T) {
+	for i, dir := range Dirs4 {
+		if got, want := RotateRight(dir), Dirs4[(i+1)%4]; got != want {
+			t.Errorf("RotateRight(%v) = %v, want %v", dir, got, want)
+		}
+		if got, want := RotateLeft(dir), Dirs4[(i+3)%4]; got != want {
+			t.Errorf("RotateLeft(%v) = %v, want %v", dir, got, want)
+		}
+		if got := RotateLeft(RotateRight(dir)); got != dir {
+			t.Errorf("RotateLeft(RotateRight(%v)) = %v", dir, got)
+		}
+	}
+	// diagonals turn too, stepping two places around Dirs8
+	for i, dir := range Dirs8 {
+		if got, want := RotateRight(dir), Dirs8[(i+2)%8]; got != want {
+			t.Errorf("RotateRight(%v) = %v, want %v", dir, got, want)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := parseSample(t)
+	tests := []struct {
+		p      Point
+		n4, n8 int
+	}{
+		{Point{1, 1}, 4, 8}, // middle
+		{Point{0, 0}, 2, 3}, // corner
+		{Point{1, 0}, 3, 5}, // edge
+	}
+	for _, tt := range tests {
+		n4 := slices.Collect(g.Neighbors4(tt.p))
+		n8 := slices.Collect(g.Neighbors8(tt.p))
+		if len(n4) != tt.n4 || len(n8) != tt.n8 {
+			t.Errorf("%v has neighbors %v and %v, want %d and %d", tt.p, n4, n8, tt.n4, tt.n8)
+		}
+		for _, n := range n8 {
+			if !g.InBounds(n) {
+				t.Errorf("%v has neighbor %v out of bounds", tt.p, n)
+			}
+		}
+	}
+	// clockwise from Up, like Dirs4
+	if got, want := slices.Collect(g.Neighbors4(Point{1, 1})), []Point{{1, 0}, {2, 1}, {1, 2}, {0, 1}}; !slices.Equal(got, want) {
+		t.Errorf("Neighbors4 = %v, want %v", got, want)
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := parseSample(t)
+	c := g.Clone()
+	c.Set(Point{1, 1}, 'X')
+	if g.At(Point{1, 1}) != 'b' || c.At(Point{1, 1}) != 'X' {
+		t.Errorf("Clone shares cells: %q and %q", g.At(Point{1, 1}), c.At(Point{1, 1}))
+	}
+	if c.Extent() != g.Extent() {
+		t.Errorf("Clone extent %v, want %v", c.Extent(), g.Extent())
+	}
+}
+
+func TestFind(t *testing.T) {
+	g := parseSample(t)
+	isA := func(c byte) bool { return c == 'a' }
+	if p, ok := g.Find(isA); !ok || p != (Point{2, 0}) {
+		t.Errorf("Find = %v, %v, want (2,0)", p, ok)
+	}
+	if p, ok := g.Find(func(c byte) bool { return c == 'z' }); ok {
+		t.Errorf("Find of nothing = %v", p)
+	}
+	if got, want := g.FindAll(isA), []Point{{2, 0}, {0, 2}}; !slices.Equal(got, want) {
+		t.Errorf("FindAll = %v, want %v", got, want)
+	}
+	if got := g.FindAll(func(c byte) bool { return c == 'z' }); got != nil {
+		t.Errorf("FindAll of nothing = %v", got)
+	}
+	if got := g.Count(func(c byte) bool { return c == '.' }); got != 4 {
+		t.Errorf("Count = %d, want 4", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		line, col int
+	}{
+		{"empty", "", 1, 0},
+		{"ragged", "##\n#\n", 2, 0},
+		{"bad cell", "..\n.x\n", 2, 2},
+	}
+	for _, tt := range tests {
+		_, err := ParseOnly(tt.text, ".#")
+		var perr *parse.Error
+		if !errors.As(err, &perr) {
+			t.Errorf("%s: got %v, want a *parse.Error", tt.name, err)
+		} else if perr.Line != tt.line || perr.Col != tt.col {
+			t.Errorf("%s: error at %d:%d, want %d:%d", tt.name, perr.Line, perr.Col, tt.line, tt.col)
+		}
+	}
+
+	// the cell func's own error is kept
+	errBad := errors.New("bad")
+	_, err := ParseFunc("ab\n", func(c byte) (int, error) {
+		if c == 'b' {
+			return 0, errBad
+		}
+		return int(c), nil
+	})
+	if !errors.Is(err, errBad) {
+		t.Errorf("ParseFunc: got %v, want errBad", err)
+	}
+}
+
+func TestRender(t *testing.T) {
+	g := parseSample(t)
+	if got, want := Text(g), sample[1:]+"\n"; got != want {
+		t.Errorf("Text = %q, want %q", got, want)
+	}
+
+	walls := New[bool](3, 2)
+	walls.Set(Point{0, 0}, true)
+	walls.Set(Point{2, 1}, true)
+	got := walls.Render(func(wall bool) byte {
+		if wall {
+			return '#'
+		}
+		return '.'
+	})
+	if want := "#..\n..#\n"; got != want {
+		t.Errorf("Render = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,211 @@
+// Package memo caches the results of pure functions by their arguments,
+// for solvers that would otherwise compute them again and again.
+//
+// A Cache is typed by its keys and values, may be bounded, evicting the
+// least recently used value to stay within its limit, and counts its hits
+// and misses, which log well:
+//
+//	counts := memo.New[Pair, int](0)
+//	...
+//	aoc.Logger(ctx).Info("memo", "counts", counts.Stats())
+//
+// A Cache scoped to one solve, or cleared between independent searches,
+// never outgrows what that search needs.  Sync is a Cache safe for
+// concurrent use.
+package memo
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Stats counts a cache's lookups.
+type Stats struct {
+	Hits, Misses int // lookups that found a value, or didn't
+	Evictions    int // values dropped to stay within the limit
+	Size         int // values held
+}
+
+// HitRate is the fraction of lookups that found a value, 0 if none.
+func (s Stats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s Stats) String() string {
+	return fmt.Sprintf("%d hits, %d misses (%.1f%% hit), %d evictions, %d held",
+		s.Hits, s.Misses, 100*s.HitRate(), s.Evictions, s.Size)
+}
+
+func (s Stats) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.Int("hits", s.Hits),
+		slog.Int("misses", s.Misses),
+		slog.String("hit_rate", fmt.Sprintf("%.1f%%", 100*s.HitRate())),
+		slog.Int("evictions", s.Evictions),
+		slog.Int("size", s.Size))
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Cache maps keys to the values computed for them.  It is not safe for
+// concurrent use; see Sync.
+type Cache[K comparable, V any] struct {
+	limit   int
+	values  map[K]V            // if unbounded
+	entries map[K]*entry[K, V] // if bounded, with the lru ring
+	lru     entry[K, V]        // sentinel of a ring, most recently used next
+	stats   Stats
+}
+
+// entry is a cached value in a bounded cache, linked by recency of use
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	prev, next *entry[K, V]
+}
+
+// New returns an empty cache holding at most limit values, or any number
+// if limit is 0.
+func New[K comparable, V any](limit int) *Cache[K, V] {
+	c := &Cache[K, V]{limit: max(limit, 0)}
+	if c.limit == 0 {
+		c.values = make(map[K]V)
+	} else {
+		c.entries = make(map[K]*entry[K, V])
+	}
+	c.lru.prev, c.lru.next = &c.lru, &c.lru
+	return c
+}
+
+// Get returns key's value, and whether it is cached.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	if c.limit == 0 {
+		v, ok := c.values[key]
+		c.count(ok)
+		return v, ok
+	}
+	e, ok := c.entries[key]
+	c.count(ok)
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.unlink(e)
+	c.pushFront(e)
+	return e.value, true
+}
+
+// Put caches value for key, evicting the least recently used value if the
+// cache is full.
+func (c *Cache[K, V]) Put(key K, value V) {
+	if c.limit == 0 {
+		c.values[key] = value
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		e.value = value
+		c.unlink(e)
+		c.pushFront(e)
+		return
+	}
+	e := &entry[K, V]{key: key, value: value}
+	c.entries[key] = e
+	c.pushFront(e)
+	if len(c.entries) > c.limit {
+		oldest := c.lru.prev
+		c.unlink(oldest)
+		delete(c.entries, oldest.key)
+		c.stats.Evictions++
+	}
+}
+
+// Len returns how many values are cached.
+func (c *Cache[K, V]) Len() int { return len(c.values) + len(c.entries) }
+
+// Clear drops every value, as when starting a search they don't apply to.
+// The stats carry on counting.
+func (c *Cache[K, V]) Clear() {
+	clear(c.values)
+	clear(c.entries)
+	c.lru.prev, c.lru.next = &c.lru, &c.lru
+}
+
+// Stats returns the cache's counts so far.
+func (c *Cache[K, V]) Stats() Stats {
+	s := c.stats
+	s.Size = c.Len()
+	return s
+}
+
+// count counts a lookup
+func (c *Cache[K, V]) count(hit bool) {
+	if hit {
+		c.stats.Hits++
+	} else {
+		c.stats.Misses++
+	}
+}
+
+func (c *Cache[K, V]) pushFront(e *entry[K, V]) {
+	e.prev, e.next = &c.lru, c.lru.next
+	e.prev.next, e.next.prev = e, e
+}
+
+func (c *Cache[K, V]) unlink(e *entry[K, V]) {
+	e.prev.next, e.next.prev = e.next, e.prev
+	e.prev, e.next = nil, nil
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Sync is a Cache safe for concurrent use.  Two goroutines missing the same
+// key both compute its value, so values must not depend on which is kept.
+type Sync[K comparable, V any] struct {
+	mu    sync.Mutex
+	cache *Cache[K, V]
+}
+
+// NewSync returns an empty Sync holding at most limit values, or any
+// number if limit is 0.
+func NewSync[K comparable, V any](limit int) *Sync[K, V] {
+	return &Sync[K, V]{cache: New[K, V](limit)}
+}
+
+// Get returns key's value, and whether it is cached.
+func (s *Sync[K, V]) Get(key K) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Get(key)
+}
+
+// Put caches value for key.
+func (s *Sync[K, V]) Put(key K, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Put(key, value)
+}
+
+// Len returns how many values are cached.
+func (s *Sync[K, V]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Len()
+}
+
+// Clear drops every value.
+func (s *Sync[K, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cache.Clear()
+}
+
+// Stats returns the cache's counts so far.
+func (s *Sync[K, V]) Stats() Stats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cache.Stats()
+}
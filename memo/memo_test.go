@@ -0,0 +1,99 @@
+package memo
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestCache(t *testing.T) {
+	c := New[string, int](0)
+	if _, ok := c.Get("a"); ok {
+		t.Error("empty cache has a")
+	}
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("a", 3)
+	if v, ok := c.Get("a"); !ok || v != 3 {
+		t.Errorf("Get(a) = %d, %v; want 3", v, ok)
+	}
+	if got, want := c.Stats(), (Stats{Hits: 1, Misses: 1, Size: 2}); got != want {
+		t.Errorf("Stats = %+v, want %+v", got, want)
+	}
+
+	c.Clear()
+	if _, ok := c.Get("a"); ok || c.Len() != 0 {
+		t.Error("cleared cache still has a")
+	}
+	if s := c.Stats(); s.Hits != 1 || s.Misses != 2 {
+		t.Errorf("Stats after Clear = %+v, want them counted on", s)
+	}
+}
+
+func TestLRU(t *testing.T) {
+	c := New[int, int](3)
+	for i := 1; i <= 3; i++ {
+		c.Put(i, i*10)
+	}
+	c.Get(1)     // 2 is now least recently used
+	c.Put(4, 40) // evicting it
+	c.Put(3, 30) // using 3 again, so 1 goes next
+	c.Put(5, 50)
+	for key, want := range map[int]bool{1: false, 2: false, 3: true, 4: true, 5: true} {
+		if _, ok := c.Get(key); ok != want {
+			t.Errorf("Get(%d) cached %v, want %v", key, ok, want)
+		}
+	}
+	if s := c.Stats(); s.Evictions != 2 || s.Size != 3 {
+		t.Errorf("Stats = %+v, want 2 evictions of 3 held", s)
+	}
+
+	c.Clear()
+	c.Put(6, 60)
+	if v, ok := c.Get(6); !ok || v != 60 || c.Len() != 1 {
+		t.Errorf("after Clear, Get(6) = %d, %v of %d held", v, ok, c.Len())
+	}
+}
+
+func TestStats(t *testing.T) {
+	s := Stats{Hits: 3, Misses: 1, Evictions: 2, Size: 5}
+	if got, want := s.String(), "3 hits, 1 misses (75.0% hit), 2 evictions, 5 held"; got != want {
+		t.Errorf("String = %q, want %q", got, want)
+	}
+	if (Stats{}).HitRate() != 0 {
+		t.Error("HitRate of no lookups should be 0")
+	}
+}
+
+func TestSync(t *testing.T) {
+	c := NewSync[int, int](100)
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				if v, ok := c.Get(i % 200); ok && v != i%200*2 {
+					t.Errorf("Get(%d) = %d", i%200, v)
+				}
+				c.Put(i%200, i%200*2)
+			}
+		}()
+	}
+	wg.Wait()
+	if s := c.Stats(); s.Hits+s.Misses != 8000 || c.Len() != 100 {
+		t.Errorf("Stats = %+v of %d held, want 8000 lookups and 100 held", s, c.Len())
+	}
+}
+
+func BenchmarkCache(b *testing.B) {
+	for _, limit := range []int{0, 512} {
+		c := New[int, int](limit)
+		b.Run(map[int]string{0: "unbounded", 512: "lru"}[limit], func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, ok := c.Get(i % 1024); !ok {
+					c.Put(i%1024, i)
+				}
+			}
+		})
+	}
+}
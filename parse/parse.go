@@ -0,0 +1,140 @@
+// Package parse has helpers for strict puzzle input parsing.
+//
+// Parsers report problems as *Error, annotated with the 1-based line and
+// column of the input.  The runner names the input with Named, so errors
+// read like "7.txt:12: expected '<result>: <args>'".
+package parse
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Error is a parse error at a position in a named input.
+// Zero Line or Col means the position is unknown.
+type Error struct {
+	Name string
+	Line int
+	Col  int
+	Err  error
+}
+
+func (e *Error) Error() string {
+	var sb strings.Builder
+	if e.Name != "" {
+		sb.WriteString(e.Name)
+		sb.WriteByte(':')
+	}
+	if e.Line > 0 {
+		sb.WriteString(strconv.Itoa(e.Line))
+		sb.WriteByte(':')
+		if e.Col > 0 {
+			sb.WriteString(strconv.Itoa(e.Col))
+			sb.WriteByte(':')
+		}
+	}
+	if sb.Len() > 0 {
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(e.Err.Error())
+	return sb.String()
+}
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// Errorf returns an *Error at line.
+func Errorf(line int, format string, args ...any) error {
+	return &Error{Line: line, Err: fmt.Errorf(format, args...)}
+}
+
+// ErrorfAt returns an *Error at line and col.
+func ErrorfAt(line, col int, format string, args ...any) error {
+	return &Error{Line: line, Col: col, Err: fmt.Errorf(format, args...)}
+}
+
+// Named sets the input name on err if it is an *Error.
+func Named(err error, name string) error {
+	var perr *Error
+	if !errors.As(err, &perr) {
+		return err
+	}
+	named := *perr
+	named.Name = name
+	return &named
+}
+
+// Offset shifts the line of err if it is an *Error, for errors reported
+// from a section that starts after line offset of the whole input.
+func Offset(err error, offset int) error {
+	var perr *Error
+	if !errors.As(err, &perr) || perr.Line == 0 {
+		return err
+	}
+	shifted := *perr
+	shifted.Line += offset
+	return &shifted
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Read reads all of r as puzzle text.
+func Read(r io.Reader) (string, error) {
+	data, err := io.ReadAll(r)
+	return string(data), err
+}
+
+// Lines splits text into lines, ignoring trailing newlines and carriage returns.
+// Empty text has no lines.
+func Lines(text string) []string {
+	text = strings.TrimRight(text, "\r\n")
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+// Sections splits lines into blank-line separated groups, returning each
+// group with the 0-based index of its first line.
+func Sections(lines []string) (sections [][]string, starts []int) {
+	start := 0
+	for i := 0; i <= len(lines); i++ {
+		if i == len(lines) || lines[i] == "" {
+			if i > start {
+				sections = append(sections, lines[start:i])
+				starts = append(starts, start)
+			}
+			start = i + 1
+		}
+	}
+	return sections, starts
+}
+
+// Int parses a base-10 int from s, reporting errors at line.
+func Int(line int, s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, Errorf(line, "expected an integer, got %q", s)
+	}
+	return n, nil
+}
+
+// Ints parses whitespace-separated ints from s, reporting errors at line.
+func Ints(line int, s string) ([]int, error) {
+	fields := strings.Fields(s)
+	nums := make([]int, 0, len(fields))
+	for _, field := range fields {
+		n, err := Int(line, field)
+		if err != nil {
+			return nil, err
+		}
+		nums = append(nums, n)
+	}
+	return nums, nil
+}
@@ -0,0 +1,31 @@
+package parse
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorFormat(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{Errorf(12, "expected '<result>: <args>'"), "12: expected '<result>: <args>'"},
+		{Named(Errorf(12, "expected '<result>: <args>'"), "7.txt"), "7.txt:12: expected '<result>: <args>'"},
+		{Named(ErrorfAt(3, 4, "bad"), "6.txt"), "6.txt:3:4: bad"},
+		{Named(Offset(Errorf(1, "bad"), 10), "x"), "x:11: bad"},
+		{Named(errors.New("plain"), "x"), "plain"},
+	}
+	for _, tt := range tests {
+		if got := tt.err.Error(); got != tt.want {
+			t.Errorf("got %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestSections(t *testing.T) {
+	sections, starts := Sections(Lines("a\nb\n\nc\n\n\nd\n"))
+	if len(sections) != 3 || starts[0] != 0 || starts[1] != 3 || starts[2] != 6 {
+		t.Errorf("got %v %v", sections, starts)
+	}
+}
@@ -0,0 +1,94 @@
+// Package replay records simulations as a history of frames, to play back
+// later, e.g. with the viewer package.
+//
+// A frame is a snapshot of a byte grid, drawn with the same glyphs the days
+// print, so any simulation that can draw itself as a grid can be replayed.
+package replay
+
+import (
+	"github.com/neomantra/aoc2024/grid"
+)
+
+// Frame is one recorded step of a simulation.
+type Frame struct {
+	Step    int    // simulation step the frame shows, counting from 0
+	Caption string // what happened, e.g. "move 12: ^"
+	Grid    *grid.Grid[byte]
+}
+
+// DefaultMaxFrames is how many frames a Recorder keeps by default.
+const DefaultMaxFrames = 1000
+
+// Recorder collects frames as a simulation runs.
+//
+// It keeps at most Max frames, evenly spread over the run, plus the final
+// state: when full, it drops every other frame and records half as often
+// from then on.  The zero Recorder keeps DefaultMaxFrames.
+type Recorder struct {
+	Max int
+
+	frames []Frame
+	stride int // steps between kept frames
+	step   int // steps recorded so far
+}
+
+// NewRecorder returns a recorder keeping at most maxFrames frames, or
+// DefaultMaxFrames if maxFrames is not positive.
+func NewRecorder(maxFrames int) *Recorder {
+	return &Recorder{Max: maxFrames}
+}
+
+// Record notes the state g after a step of the simulation, or the initial
+// state on the first call.  g is copied if the frame is kept.
+func (r *Recorder) Record(g *grid.Grid[byte], caption string) {
+	if r.stride == 0 {
+		r.stride = 1
+	}
+	step := r.step
+	r.step++
+	if step%r.stride != 0 {
+		return
+	}
+	r.frames = append(r.frames, Frame{Step: step, Caption: caption, Grid: g.Clone()})
+	limit := r.Max
+	if limit <= 0 {
+		limit = DefaultMaxFrames
+	}
+	if len(r.frames) > max(limit, 2) {
+		r.stride *= 2
+		kept := r.frames[:0]
+		for _, f := range r.frames {
+			if f.Step%r.stride == 0 {
+				kept = append(kept, f)
+			}
+		}
+		clear(r.frames[len(kept):])
+		r.frames = kept
+	}
+}
+
+// Done records the final state g, which is the state after the last step
+// recorded, so it is kept even if Record skipped it.  caption, e.g. the
+// outcome, replaces the last step's.
+func (r *Recorder) Done(g *grid.Grid[byte], caption string) {
+	if r.step == 0 {
+		r.Record(g, caption)
+		return
+	}
+	final := Frame{Step: r.step - 1, Caption: caption, Grid: g.Clone()}
+	if n := len(r.frames); n > 0 && r.frames[n-1].Step == final.Step {
+		r.frames[n-1] = final
+		return
+	}
+	r.frames = append(r.frames, final)
+}
+
+// Frames returns the kept frames, in step order.
+func (r *Recorder) Frames() []Frame {
+	return r.frames
+}
+
+// Steps returns how many steps were recorded, kept or not.
+func (r *Recorder) Steps() int {
+	return r.step
+}
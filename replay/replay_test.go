@@ -0,0 +1,51 @@
+package replay
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/neomantra/aoc2024/grid"
+)
+
+func TestRecorder(t *testing.T) {
+	g := grid.New[byte](1, 1)
+	rec := NewRecorder(10)
+	for step := 0; step < 100; step++ {
+		g.Set(grid.Point{}, byte(step))
+		rec.Record(g, fmt.Sprint("step ", step))
+	}
+	g.Set(grid.Point{}, 99)
+	rec.Done(g, "done")
+
+	frames := rec.Frames()
+	if len(frames) > 11 {
+		t.Errorf("kept %d frames, want at most 10 and the final one", len(frames))
+	}
+	if frames[0].Step != 0 {
+		t.Errorf("first frame is step %d, want 0", frames[0].Step)
+	}
+	last := frames[len(frames)-1]
+	if last.Step != 99 || last.Caption != "done" || last.Grid.At(grid.Point{}) != 99 {
+		t.Errorf("final frame is %+v, want step 99 captioned done", last)
+	}
+	stride := frames[1].Step - frames[0].Step
+	for i, f := range frames[:len(frames)-1] {
+		if f.Step != i*stride {
+			t.Errorf("frame %d is step %d, want evenly spread every %d", i, f.Step, stride)
+		}
+		if f.Grid.At(grid.Point{}) != byte(f.Step) {
+			t.Errorf("frame %d shows step %d, want a snapshot of step %d", i, f.Grid.At(grid.Point{}), f.Step)
+		}
+	}
+	if rec.Steps() != 100 {
+		t.Errorf("Steps() = %d, want 100", rec.Steps())
+	}
+}
+
+func TestRecorderDoneOnly(t *testing.T) {
+	var rec Recorder
+	rec.Done(grid.New[byte](2, 2), "nothing happened")
+	if frames := rec.Frames(); len(frames) != 1 || frames[0].Step != 0 {
+		t.Errorf("got %+v, want the single final frame", frames)
+	}
+}
@@ -0,0 +1,265 @@
+// Package viewer plays back recorded simulation frames in the terminal,
+// with Bubble Tea.
+package viewer
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+// keyMap defines a set of keybindings. To work for help it must satisfy
+// key.Map. It could also very easily be a map[string]key.Binding.
+type keyMap struct {
+	Play   key.Binding
+	Step   key.Binding
+	Back   key.Binding
+	Rewind key.Binding
+	End    key.Binding
+	Faster key.Binding
+	Slower key.Binding
+	Up     key.Binding
+	Down   key.Binding
+	Left   key.Binding
+	Right  key.Binding
+	Quit   key.Binding
+}
+
+// ShortHelp returns keybindings to be shown in the mini help view. It's part of the key.Map interface.
+func (k keyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Quit, k.Play, k.Step, k.Back, k.Rewind, k.Faster, k.Slower}
+}
+
+// FullHelp returns keybindings for the expanded help view. It's part of the key.Map interface.
+func (k keyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{
+		{k.Quit, k.Play, k.Step, k.Back, k.Rewind, k.End},
+		{k.Faster, k.Slower, k.Up, k.Down, k.Left, k.Right},
+	}
+}
+
+var keys = keyMap{
+	Play: key.NewBinding(
+		key.WithKeys(" ", "p"),
+		key.WithHelp("space", "play/pause"),
+	),
+	Step: key.NewBinding(
+		key.WithKeys("right", "l", "s"),
+		key.WithHelp("→", "step"),
+	),
+	Back: key.NewBinding(
+		key.WithKeys("left", "h", "b"),
+		key.WithHelp("←", "back"),
+	),
+	Rewind: key.NewBinding(
+		key.WithKeys("home", "r"),
+		key.WithHelp("r", "rewind"),
+	),
+	End: key.NewBinding(
+		key.WithKeys("end", "e"),
+		key.WithHelp("e", "end"),
+	),
+	Faster: key.NewBinding(
+		key.WithKeys("+", "="),
+		key.WithHelp("+", "faster"),
+	),
+	Slower: key.NewBinding(
+		key.WithKeys("-", "_"),
+		key.WithHelp("-", "slower"),
+	),
+	Up: key.NewBinding(
+		key.WithKeys("up", "k"),
+		key.WithHelp("↑", "scroll up"),
+	),
+	Down: key.NewBinding(
+		key.WithKeys("down", "j"),
+		key.WithHelp("↓", "scroll down"),
+	),
+	Left: key.NewBinding(
+		key.WithKeys("shift+left", "["),
+		key.WithHelp("[", "scroll left"),
+	),
+	Right: key.NewBinding(
+		key.WithKeys("shift+right", "]"),
+		key.WithHelp("]", "scroll right"),
+	),
+	Quit: key.NewBinding(
+		key.WithKeys("q", "esc", "ctrl+c"),
+		key.WithHelp("q", "quit"),
+	),
+}
+
+// speeds are the playback rates, in frames per second
+var speeds = []int{1, 2, 5, 10, 20, 50, 100}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// TModel is a Bubble Tea model playing back frames.
+type TModel struct {
+	title  string
+	frames []replay.Frame
+
+	cur     int  // frame shown
+	playing bool // advancing on each tick
+	speed   int  // index into speeds
+	ticks   int  // identifies the current tick chain, so stale ticks are dropped
+
+	top, left     int // scroll offset of the grid
+	width, height int // terminal size, 0 until known
+
+	keys keyMap
+	help help.Model
+}
+
+// tickMsg advances playback
+type tickMsg struct{ id int }
+
+// NewTModel returns a model paused on the first of frames.
+func NewTModel(title string, frames []replay.Frame) *TModel {
+	return &TModel{
+		title:  title,
+		frames: frames,
+		speed:  3,
+		keys:   keys,
+		help:   help.New(),
+	}
+}
+
+// Play runs the viewer on frames until the user quits.
+func Play(title string, frames []replay.Frame) error {
+	if len(frames) == 0 {
+		return fmt.Errorf("no frames to replay")
+	}
+	_, err := tea.NewProgram(NewTModel(title, frames), tea.WithAltScreen()).Run()
+	return err
+}
+
+func (tm *TModel) Init() tea.Cmd {
+	return nil
+}
+
+// tick schedules the next frame of the current tick chain
+func (tm *TModel) tick() tea.Cmd {
+	id := tm.ticks
+	return tea.Tick(time.Second/time.Duration(speeds[tm.speed]), func(time.Time) tea.Msg {
+		return tickMsg{id: id}
+	})
+}
+
+// setPlaying starts or stops playback, starting a new tick chain
+func (tm *TModel) setPlaying(playing bool) tea.Cmd {
+	tm.playing = playing
+	tm.ticks++
+	if !playing {
+		return nil
+	}
+	if tm.cur == len(tm.frames)-1 {
+		tm.cur = 0 // play again from the start
+	}
+	return tm.tick()
+}
+
+// seek shows frame i, clamped to the recording
+func (tm *TModel) seek(i int) {
+	tm.cur = max(0, min(i, len(tm.frames)-1))
+}
+
+func (tm *TModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		// If we set a width on the help menu it can gracefully truncate
+		// its view as needed.
+		tm.help.Width = msg.Width
+		tm.width, tm.height = msg.Width, msg.Height
+		return tm, nil
+
+	case tickMsg:
+		if !tm.playing || msg.id != tm.ticks {
+			return tm, nil
+		}
+		tm.seek(tm.cur + 1)
+		if tm.cur == len(tm.frames)-1 {
+			tm.playing = false
+			return tm, nil
+		}
+		return tm, tm.tick()
+
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, tm.keys.Quit):
+			return tm, tea.Quit
+		case key.Matches(msg, tm.keys.Play):
+			return tm, tm.setPlaying(!tm.playing)
+		case key.Matches(msg, tm.keys.Step):
+			tm.setPlaying(false)
+			tm.seek(tm.cur + 1)
+		case key.Matches(msg, tm.keys.Back):
+			tm.setPlaying(false)
+			tm.seek(tm.cur - 1)
+		case key.Matches(msg, tm.keys.Rewind):
+			tm.setPlaying(false)
+			tm.seek(0)
+		case key.Matches(msg, tm.keys.End):
+			tm.setPlaying(false)
+			tm.seek(len(tm.frames) - 1)
+		case key.Matches(msg, tm.keys.Faster):
+			tm.speed = min(tm.speed+1, len(speeds)-1)
+		case key.Matches(msg, tm.keys.Slower):
+			tm.speed = max(tm.speed-1, 0)
+		case key.Matches(msg, tm.keys.Up):
+			tm.top = max(tm.top-1, 0)
+		case key.Matches(msg, tm.keys.Down):
+			tm.top++
+		case key.Matches(msg, tm.keys.Left):
+			tm.left = max(tm.left-4, 0)
+		case key.Matches(msg, tm.keys.Right):
+			tm.left += 4
+		}
+	}
+	return tm, nil
+}
+
+func (tm TModel) View() string {
+	frame := tm.frames[tm.cur]
+	border := lipgloss.NewStyle().BorderStyle(lipgloss.NormalBorder())
+
+	state := "paused"
+	if tm.playing {
+		state = "playing"
+	}
+	status := fmt.Sprintf("frame %d/%d  step %d  %s at %d/s",
+		tm.cur+1, len(tm.frames), frame.Step, state, speeds[tm.speed])
+
+	return lipgloss.JoinVertical(lipgloss.Left,
+		tm.title+" - "+frame.Caption,
+		border.Render(tm.gridView(frame.Grid)),
+		status,
+		tm.help.View(tm.keys))
+}
+
+// gridView draws the part of g that fits the terminal, from the scroll offset
+func (tm TModel) gridView(g *grid.Grid[byte]) string {
+	lines := strings.Split(strings.TrimSuffix(grid.Text(g), "\n"), "\n")
+	rows, cols := len(lines), g.Width()
+	if tm.height > 0 {
+		rows = max(tm.height-5, 1) // title, border, status and help
+	}
+	if tm.width > 0 {
+		cols = max(tm.width-2, 1)
+	}
+	top := min(tm.top, max(len(lines)-rows, 0))
+	left := min(tm.left, max(g.Width()-cols, 0))
+
+	lines = lines[top:min(top+rows, len(lines))]
+	for i, line := range lines {
+		lines[i] = line[left:min(left+cols, len(line))]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,89 @@
+package viewer
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/neomantra/aoc2024/grid"
+	"github.com/neomantra/aoc2024/replay"
+)
+
+func press(tm *TModel, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, k := range keys {
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		switch k {
+		case "right":
+			msg = tea.KeyMsg{Type: tea.KeyRight}
+		case "left":
+			msg = tea.KeyMsg{Type: tea.KeyLeft}
+		}
+		_, cmd = tm.Update(msg)
+	}
+	return cmd
+}
+
+func TestTModel(t *testing.T) {
+	var frames []replay.Frame
+	for i := 0; i < 5; i++ {
+		g := grid.New[byte](20, 10)
+		g.Fill('.')
+		g.Set(grid.Point{X: i, Y: i}, '@')
+		frames = append(frames, replay.Frame{Step: i * 2, Caption: fmt.Sprint("move ", i), Grid: g})
+	}
+	tm := NewTModel("Day 0", frames)
+
+	press(tm, "right", "right", "l")
+	if tm.cur != 3 {
+		t.Errorf("after stepping 3 times, at frame %d", tm.cur)
+	}
+	press(tm, "left")
+	if tm.cur != 2 {
+		t.Errorf("after stepping back, at frame %d", tm.cur)
+	}
+	press(tm, "e", "right")
+	if tm.cur != 4 {
+		t.Errorf("stepping past the end: at frame %d, want the last", tm.cur)
+	}
+	press(tm, "r", "left")
+	if tm.cur != 0 {
+		t.Errorf("stepping back past the start: at frame %d, want the first", tm.cur)
+	}
+
+	// playing advances a frame per tick, and stops at the end
+	if cmd := press(tm, " "); cmd == nil || !tm.playing {
+		t.Fatal("space should start playing")
+	}
+	stale := tickMsg{id: tm.ticks - 1}
+	tm.Update(stale)
+	if tm.cur != 0 {
+		t.Errorf("a stale tick moved to frame %d", tm.cur)
+	}
+	for i := 0; i < 10; i++ {
+		tm.Update(tickMsg{id: tm.ticks})
+	}
+	if tm.cur != 4 || tm.playing {
+		t.Errorf("after playing through: at frame %d, playing %v", tm.cur, tm.playing)
+	}
+
+	press(tm, "+", "+", "+", "+", "+", "+", "+", "+")
+	if speeds[tm.speed] != speeds[len(speeds)-1] {
+		t.Errorf("speed %d/s, want the fastest", speeds[tm.speed])
+	}
+
+	// the grid is cropped to the terminal
+	tm.Update(tea.WindowSizeMsg{Width: 12, Height: 9})
+	press(tm, "j", "j")
+	view := tm.View()
+	if !strings.Contains(view, "move 4") || !strings.Contains(view, "frame 5/5  step 8") {
+		t.Errorf("view lacks the caption or status:\n%s", view)
+	}
+	if rows := strings.Count(tm.gridView(frames[4].Grid), "\n") + 1; rows != 4 {
+		t.Errorf("cropped grid has %d rows, want 4", rows)
+	}
+	if got := strings.Split(tm.gridView(frames[4].Grid), "\n")[2]; got != "....@....." {
+		t.Errorf("scrolled row is %q, want the robot's", got)
+	}
+}
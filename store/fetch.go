@@ -0,0 +1,23 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// ErrOffline is returned by fetchers that are not wired up to the network.
+var ErrOffline = errors.New("fetching inputs is not supported, save the input to the store or N/N.txt")
+
+// HTTPFetcher fetches inputs from adventofcode.com with a session cookie.
+//
+// It is a stub for now: the store is offline-only, and Fetch always
+// returns ErrOffline.
+type HTTPFetcher struct {
+	Session string
+	Client  *http.Client
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, year, day int) (string, error) {
+	return "", ErrOffline
+}
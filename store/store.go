@@ -0,0 +1,299 @@
+// Package store is a local cache of puzzle inputs and a history of answers.
+//
+// It lives in a directory keyed by year and day:
+//
+//	<dir>/2024/6/input.txt    cached puzzle input
+//	<dir>/2024/6/part1.json   answer history and verdicts for part 1
+//
+// Every computed answer is recorded with when it was computed, the solver
+// version and a hash of its input, so a change between runs can be flagged.
+// Answers can be marked confirmed-correct or known-wrong, so a known-bad
+// value is not submitted twice.  The store is entirely offline.
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Year is the Advent of Code year this store's days belong to.
+const Year = 2024
+
+// Verdict is what is known about an answer's correctness.
+type Verdict string
+
+const (
+	Unknown Verdict = ""
+	Correct Verdict = "correct"
+	Wrong   Verdict = "wrong"
+)
+
+// ParseVerdict parses "correct" or "wrong".
+func ParseVerdict(s string) (Verdict, error) {
+	switch v := Verdict(s); v {
+	case Correct, Wrong:
+		return v, nil
+	default:
+		return Unknown, fmt.Errorf("bad verdict %q, expected correct or wrong", s)
+	}
+}
+
+// Record is one computed answer.
+type Record struct {
+	Answer    string    `json:"answer"`
+	Time      time.Time `json:"time"`
+	Version   string    `json:"version"`
+	InputHash string    `json:"input_hash"`
+}
+
+// Mark is a verdict on an answer for an input.
+type Mark struct {
+	Answer    string    `json:"answer"`
+	InputHash string    `json:"input_hash"`
+	Verdict   Verdict   `json:"verdict"`
+	Time      time.Time `json:"time"`
+}
+
+// Part is everything stored about one part of a day.
+type Part struct {
+	History []Record `json:"history"`
+	Marks   []Mark   `json:"marks"`
+}
+
+// Verdict returns the verdict on answer for an input.
+// An answer differing from one confirmed correct is Wrong.
+func (p *Part) Verdict(inputHash, answer string) Verdict {
+	verdict := Unknown
+	for _, m := range p.Marks {
+		if m.InputHash != inputHash {
+			continue
+		}
+		if m.Answer == answer {
+			return m.Verdict
+		}
+		if m.Verdict == Correct {
+			verdict = Wrong
+		}
+	}
+	return verdict
+}
+
+// Correct returns the answer confirmed correct for an input, if any.
+func (p *Part) Correct(inputHash string) (string, bool) {
+	for _, m := range p.Marks {
+		if m.InputHash == inputHash && m.Verdict == Correct {
+			return m.Answer, true
+		}
+	}
+	return "", false
+}
+
+// Last returns the most recent record for an input, if any.
+func (p *Part) Last(inputHash string) (Record, bool) {
+	for i := len(p.History) - 1; i >= 0; i-- {
+		if p.History[i].InputHash == inputHash {
+			return p.History[i], true
+		}
+	}
+	return Record{}, false
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// Store is a directory of inputs and answers.
+type Store struct {
+	Dir string
+}
+
+// DefaultDir is $AOC_STORE, or aoc2024 in the user's cache directory.
+func DefaultDir() (string, error) {
+	if dir := os.Getenv("AOC_STORE"); dir != "" {
+		return dir, nil
+	}
+	cache, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(cache, "aoc2024"), nil
+}
+
+// Open returns the store in dir, creating the directory if needed.
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Store{Dir: dir}, nil
+}
+
+func (s *Store) dayDir(day int) string {
+	return filepath.Join(s.Dir, strconv.Itoa(Year), strconv.Itoa(day))
+}
+
+func (s *Store) partPath(day, part int) string {
+	return filepath.Join(s.dayDir(day), fmt.Sprintf("part%d.json", part))
+}
+
+// Part returns what is stored for a part; it is empty if nothing is.
+func (s *Store) Part(day, part int) (*Part, error) {
+	var p Part
+	data, err := os.ReadFile(s.partPath(day, part))
+	if errors.Is(err, os.ErrNotExist) {
+		return &p, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("%s: %w", s.partPath(day, part), err)
+	}
+	return &p, nil
+}
+
+func (s *Store) savePart(day, part int, p *Part) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(s.partPath(day, part), append(data, '\n'))
+}
+
+// writeFile writes via a temporary file, so a crash never leaves half a file
+func writeFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// Record adds an answer computed from input to the history, returning the
+// part as it was before, so the caller can compare against it.
+func (s *Store) Record(day, part int, input, answer string) (*Part, error) {
+	p, err := s.Part(day, part)
+	if err != nil {
+		return nil, err
+	}
+	before := *p
+	p.History = append(p.History, Record{
+		Answer:    answer,
+		Time:      time.Now().UTC(),
+		Version:   Version(),
+		InputHash: HashInput(input),
+	})
+	return &before, s.savePart(day, part, p)
+}
+
+// Mark records a verdict on an answer for input.
+func (s *Store) Mark(day, part int, input, answer string, verdict Verdict) error {
+	p, err := s.Part(day, part)
+	if err != nil {
+		return err
+	}
+	hash := HashInput(input)
+	if verdict == Correct {
+		if correct, ok := p.Correct(hash); ok && correct != answer {
+			return fmt.Errorf("%d.%d: %s is already marked correct", day, part, correct)
+		}
+	}
+	p.Marks = append(p.Marks, Mark{
+		Answer:    answer,
+		InputHash: hash,
+		Verdict:   verdict,
+		Time:      time.Now().UTC(),
+	})
+	return s.savePart(day, part, p)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// ErrNoInput is returned when a day's input is neither cached nor fetchable.
+var ErrNoInput = errors.New("no cached input")
+
+// Fetcher downloads a day's puzzle input.
+type Fetcher interface {
+	Fetch(ctx context.Context, year, day int) (string, error)
+}
+
+// InputPath is where a day's input is cached.
+func (s *Store) InputPath(day int) string {
+	return filepath.Join(s.dayDir(day), "input.txt")
+}
+
+// SaveInput caches a day's input.
+func (s *Store) SaveInput(day int, input string) error {
+	return writeFile(s.InputPath(day), []byte(input))
+}
+
+// Input returns a day's cached input, fetching and caching it with f if it
+// is missing.  f may be nil to stay offline.
+func (s *Store) Input(ctx context.Context, day int, f Fetcher) (string, error) {
+	data, err := os.ReadFile(s.InputPath(day))
+	if err == nil {
+		return string(data), nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	if f == nil {
+		return "", fmt.Errorf("day %d: %w", day, ErrNoInput)
+	}
+	input, err := f.Fetch(ctx, Year, day)
+	if err != nil {
+		return "", fmt.Errorf("day %d: %w", day, err)
+	}
+	return input, s.SaveInput(day, input)
+}
+
+///////////////////////////////////////////////////////////////////////////////
+
+// HashInput identifies an input by a short hash of its content.
+func HashInput(input string) string {
+	sum := sha256.Sum256([]byte(input))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Version identifies the running solver build: its module version or,
+// when built from a checkout, the VCS revision.
+func Version() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			if setting.Value == "true" {
+				modified = "+dirty"
+			}
+		}
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	switch {
+	case revision == "" || strings.Contains(version, revision):
+		// module versions stamped from a checkout already carry the revision
+	case version == "" || version == "(devel)":
+		version = revision + modified
+	default:
+		version += " " + revision + modified
+	}
+	return version
+}
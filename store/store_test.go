@@ -0,0 +1,71 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRecordAndMark(t *testing.T) {
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	const input = "some puzzle input"
+	hash := HashInput(input)
+
+	before, err := s.Record(6, 1, input, "40")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := before.Last(hash); ok {
+		t.Error("expected no history before the first record")
+	}
+	if before, err = s.Record(6, 1, input, "41"); err != nil {
+		t.Fatal(err)
+	}
+	if last, ok := before.Last(hash); !ok || last.Answer != "40" {
+		t.Errorf("Last: got %v %v, want 40", last, ok)
+	}
+
+	if err := s.Mark(6, 1, input, "41", Correct); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Mark(6, 1, input, "40", Correct); err == nil {
+		t.Error("expected an error marking a second answer correct")
+	}
+	p, err := s.Part(6, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.History) != 2 {
+		t.Errorf("got %d records, want 2", len(p.History))
+	}
+	for answer, want := range map[string]Verdict{"41": Correct, "40": Wrong} {
+		if got := p.Verdict(hash, answer); got != want {
+			t.Errorf("Verdict(%s): got %q, want %q", answer, got, want)
+		}
+	}
+	if got := p.Verdict(HashInput("other input"), "40"); got != Unknown {
+		t.Errorf("Verdict on another input: got %q, want unknown", got)
+	}
+}
+
+func TestInput(t *testing.T) {
+	s, err := Open(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Input(context.Background(), 6, nil); !errors.Is(err, ErrNoInput) {
+		t.Errorf("got %v, want ErrNoInput", err)
+	}
+	if _, err := s.Input(context.Background(), 6, &HTTPFetcher{}); !errors.Is(err, ErrOffline) {
+		t.Errorf("got %v, want ErrOffline", err)
+	}
+	if err := s.SaveInput(6, "cached"); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := s.Input(context.Background(), 6, nil); err != nil || got != "cached" {
+		t.Errorf("got %q %v, want cached", got, err)
+	}
+}